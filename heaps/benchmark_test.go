@@ -0,0 +1,223 @@
+package heaps_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/houz42/abstract/heaps"
+)
+
+func BenchmarkHeap(b *testing.B) {
+	for size := 1000; size < 1_000_000; size *= 10 {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			basicReadWrite(b, size)
+		})
+	}
+}
+
+// newHeap returns a heap containing 0 to size-1 pushed in random order,
+// with capacity enough for one more element.
+func newHeap(size int) *heaps.Heap[int] {
+	h := heaps.New(make([]int, 0, size+1)...)
+	for _, v := range rand.New(rand.NewSource(1)).Perm(size) {
+		h.Push(v)
+	}
+	return h
+}
+
+func basicReadWrite(b *testing.B, size int) {
+	perm := rand.New(rand.NewSource(1)).Perm(size)
+
+	b.Run("push", func(b *testing.B) {
+		b.Run("forward", func(b *testing.B) {
+			for x := 0; x < b.N; x++ {
+				h := heaps.New[int]()
+				for i := 0; i < size; i++ {
+					h.Push(i)
+				}
+				if h.Len() != size {
+					b.Fatal()
+				}
+			}
+		})
+
+		b.Run("backward", func(b *testing.B) {
+			for x := 0; x < b.N; x++ {
+				h := heaps.New[int]()
+				for i := size - 1; i >= 0; i-- {
+					h.Push(i)
+				}
+				if h.Len() != size {
+					b.Fatal()
+				}
+			}
+		})
+
+		b.Run("random", func(b *testing.B) {
+			for x := 0; x < b.N; x++ {
+				h := heaps.New[int]()
+				for _, i := range perm {
+					h.Push(i)
+				}
+				if h.Len() != size {
+					b.Fatal()
+				}
+			}
+		})
+
+		b.Run("from values", func(b *testing.B) {
+			values := make([]int, size)
+			b.ResetTimer()
+
+			for x := 0; x < b.N; x++ {
+				copy(values, perm)
+				h := heaps.New(values...)
+				if h.Len() != size {
+					b.Fatal()
+				}
+			}
+		})
+	})
+
+	b.Run("pop", func(b *testing.B) {
+		for x := 0; x < b.N; x++ {
+			b.StopTimer()
+			h := newHeap(size)
+			b.StartTimer()
+
+			for i := 0; i < size; i++ {
+				if h.Pop() != i {
+					b.Fatal()
+				}
+			}
+		}
+	})
+
+	b.Run("remove at", func(b *testing.B) {
+		for x := 0; x < b.N; x++ {
+			b.StopTimer()
+			h := newHeap(size)
+			b.StartTimer()
+
+			for _, i := range perm {
+				h.RemoveAt(i % h.Len())
+			}
+			if h.Len() != 0 {
+				b.Fatal()
+			}
+		}
+	})
+
+	b.Run("merge", func(b *testing.B) {
+		for _, m := range []int{size / 100, size} {
+			h2 := newHeap(m)
+
+			b.Run(fmt.Sprintf("m=%d", m), func(b *testing.B) {
+				for x := 0; x < b.N; x++ {
+					b.StopTimer()
+					h := newHeap(size)
+					b.StartTimer()
+
+					h.Merge(h2)
+					if h.Len() != size+m {
+						b.Fatal()
+					}
+				}
+			})
+		}
+	})
+
+	// push and pop on a heap with enough capacity, which should not allocate at all
+	b.Run("push pop", func(b *testing.B) {
+		h := newHeap(size)
+		b.ResetTimer()
+
+		for x := 0; x < b.N; x++ {
+			h.Push(h.Pop() + size)
+		}
+	})
+
+	b.Run("struct", func(b *testing.B) {
+		type item struct {
+			prio  int
+			value string
+		}
+		h := heaps.NewFunc(func(x, y item) bool { return x.prio < y.prio }, make([]item, 0, size+1)...)
+		for _, i := range perm {
+			h.Push(item{prio: i})
+		}
+		b.ResetTimer()
+
+		for x := 0; x < b.N; x++ {
+			v := h.Pop()
+			v.prio += size
+			h.Push(v)
+		}
+	})
+}
+
+func TestHeapAllocs(t *testing.T) {
+	h := newHeap(1000)
+	allocs := testing.AllocsPerRun(1000, func() {
+		h.Push(h.Pop() + 1000)
+	})
+	if allocs != 0 {
+		t.Errorf("push and pop allocated %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkHeaps(b *testing.B) {
+	for size := 1000; size < 1_000_000; size *= 10 {
+		values := rand.New(rand.NewSource(1)).Perm(size)
+
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			benchmarkHeap(b, "binary", values, func() *heaps.Heap[int] { return heaps.New[int]() })
+			benchmarkHeap(b, "4-ary", values, func() *heaps.DAry[int] { return heaps.NewDAry[int](4) })
+			benchmarkHeap(b, "pairing", values, func() *heaps.Pairing[int] { return heaps.NewPairing[int]() })
+			benchmarkHeap(b, "fibonacci", values, func() *heaps.Fibonacci[int] { return heaps.NewFibonacci[int]() })
+		})
+	}
+}
+
+func benchmarkHeap[H heaps.Interface[int, H]](b *testing.B, name string, values []int, newHeap func() H) {
+	b.Run(name, func(b *testing.B) {
+		b.Run("push", func(b *testing.B) {
+			for x := 0; x < b.N; x++ {
+				h := newHeap()
+				for _, v := range values {
+					h.Push(v)
+				}
+				if h.Len() != len(values) {
+					b.Fatal()
+				}
+			}
+		})
+
+		b.Run("push pop", func(b *testing.B) {
+			for x := 0; x < b.N; x++ {
+				h := newHeap()
+				for _, v := range values {
+					h.Push(v)
+				}
+				for i := range values {
+					if h.Pop() != i {
+						b.Fatal()
+					}
+				}
+			}
+		})
+
+		b.Run("interleaved", func(b *testing.B) {
+			h := newHeap()
+			for _, v := range values {
+				h.Push(v)
+			}
+			b.ResetTimer()
+
+			for x := 0; x < b.N; x++ {
+				h.Push(h.Pop() + len(values))
+			}
+		})
+	})
+}
@@ -0,0 +1,149 @@
+package heaps
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// DAry is a d-ary heap, in which each node has up to d children.
+// A DAry heap is shallower than a binary one, and the children of a node are adjacent in memory,
+// so it is more cache-friendly, and faster when pushes outnumber pops.
+// Pop compares more children on each level, so a small d like 4 is usually the best choice.
+//
+// The elements are kept in a slice and moved in place, no allocation is needed unless the slice grows.
+//
+// A DAry heap is not safe for concurrent use by multiple goroutines.
+type DAry[E any] struct {
+	values []E
+	d      int
+	less   func(x, y E) bool
+}
+
+// NewDAry creates a new d-ary min-heap for ordered element types.
+// The initial values are optional.
+// It panics if d is less than 2.
+func NewDAry[E cmp.Ordered](d int, values ...E) *DAry[E] {
+	return NewDAryFunc(d, func(x, y E) bool { return x < y }, values...)
+}
+
+// NewDAryFunc creates a new d-ary min-heap for any type.
+// The initial values are optional.
+// It panics if d is less than 2.
+func NewDAryFunc[E any](d int, less func(x, y E) bool, values ...E) *DAry[E] {
+	if d < 2 {
+		panic(fmt.Errorf("heaps: arity of a d-ary heap must be at least 2, got %d", d))
+	}
+
+	h := &DAry[E]{values: values, d: d, less: less}
+	heapify(h.values, d, less)
+	return h
+}
+
+// Len returns number of elements in the heap.
+func (h *DAry[E]) Len() int { return len(h.values) }
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n / log d) where n = h.Len().
+func (h *DAry[E]) Push(x E) *DAry[E] {
+	h.values = append(h.values, x)
+	up(h.values, len(h.values)-1, h.d, h.less)
+	return h
+}
+
+// Pop removes and returns the first element from the heap.
+// The complexity is O(d log n / log d) where n = h.Len().
+func (h *DAry[E]) Pop() E {
+	return h.RemoveAt(0)
+}
+
+// Top returns the first element from the heap.
+// The complexity is O(1).
+func (h *DAry[E]) Top() E {
+	return h.values[0]
+}
+
+// RemoveAt removes and returns the element at index i from the heap.
+// The complexity is O(d log n / log d) where n = h.Len().
+func (h *DAry[E]) RemoveAt(i int) E {
+	var x E
+	h.values, x = remove(h.values, i, h.d, h.less)
+	return x
+}
+
+// Clone returns a new heap which contains same elements in h.
+func (h *DAry[E]) Clone() *DAry[E] {
+	values := make([]E, len(h.values))
+	copy(values, h.values)
+	return &DAry[E]{values: values, d: h.d, less: h.less}
+}
+
+// heapify establishes the heap order of a d-ary heap in values.
+// The complexity is O(n).
+func heapify[E any](values []E, d int, less func(x, y E) bool) {
+	if len(values) < 2 {
+		return
+	}
+	for i := (len(values) - 2) / d; i >= 0; i-- {
+		down(values, i, d, less)
+	}
+}
+
+// remove removes values[i] from a d-ary heap, and returns the shrunk values with the removed element.
+func remove[E any](values []E, i, d int, less func(x, y E) bool) ([]E, E) {
+	n := len(values) - 1
+	x := values[i]
+	if i != n {
+		values[i] = values[n]
+	}
+
+	var zero E
+	values[n] = zero // do not hold the removed element
+	values = values[:n]
+
+	if i < n && !down(values, i, d, less) {
+		up(values, i, d, less)
+	}
+	return values, x
+}
+
+// up moves values[i] up towards the root of a d-ary heap, until its parent is not greater than it.
+func up[E any](values []E, i, d int, less func(x, y E) bool) {
+	x := values[i]
+	for i > 0 {
+		parent := (i - 1) / d
+		if !less(x, values[parent]) {
+			break
+		}
+		values[i] = values[parent]
+		i = parent
+	}
+	values[i] = x
+}
+
+// down moves values[i] down towards the leaves of a d-ary heap, until none of its children is less than it,
+// and reports whether it is moved.
+func down[E any](values []E, i, d int, less func(x, y E) bool) bool {
+	start := i
+	x := values[i]
+	for {
+		first := i*d + 1
+		if first >= len(values) || first < 0 { // first < 0 after int overflow
+			break
+		}
+
+		least := first
+		for c := first + 1; c < min(first+d, len(values)); c++ {
+			if less(values[c], values[least]) {
+				least = c
+			}
+		}
+		if !less(values[least], x) {
+			break
+		}
+
+		values[i] = values[least]
+		i = least
+	}
+	values[i] = x
+	return i > start
+}
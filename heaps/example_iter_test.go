@@ -20,3 +20,41 @@ func ExampleHeap_Drain() {
 	// 2 7
 	// 3 9
 }
+
+func ExampleMergeSorted() {
+	h := heaps.New(8, 2, 6, 20)
+	h.Push(4).Push(12)
+	drained := func(yield func(int) bool) {
+		for _, v := range h.Drain() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+
+	odd := func(yield func(int) bool) {
+		for _, v := range []int{1, 3, 5, 7, 9} {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	squares := func(yield func(int) bool) {
+		for i := 0; yield(i * i); i++ {
+		}
+	}
+
+	var merged []int
+	for v := range heaps.MergeSorted(drained, odd, squares) {
+		if v > 9 {
+			break
+		}
+		merged = append(merged, v)
+	}
+	fmt.Println(merged)
+	fmt.Println("left in the heap:", h.Len())
+
+	// Output:
+	// [0 1 1 2 3 4 4 5 6 7 8 9 9]
+	// left in the heap: 1
+}
@@ -82,3 +82,115 @@ func ExampleHeap_Reverse() {
 	// 2: buy food
 	// 1: call friend
 }
+
+func ExampleHeap_MergeAll() {
+	h := heaps.New(5, 1)
+	h.MergeAll(heaps.New(4, 2), heaps.New(6, 3))
+
+	for h.Len() > 0 {
+		fmt.Printf("%d ", h.Pop())
+	}
+
+	// Output:
+	// 1 2 3 4 5 6
+}
+
+func ExampleIndexed() {
+	type job struct {
+		name     string
+		priority int
+	}
+
+	queue := heaps.NewIndexedFunc[job](func(x, y job) bool { return x.priority < y.priority })
+
+	build := queue.Push(job{name: "build", priority: 2})
+	queue.Push(job{name: "test", priority: 3})
+	deploy := queue.Push(job{name: "deploy", priority: 4})
+	lint := queue.Push(job{name: "lint", priority: 1})
+
+	// deploy as soon as possible, and skip linting
+	queue.Update(deploy, job{name: "deploy", priority: 0})
+	queue.Remove(lint)
+	fmt.Println(lint.Valid(), build.Valid())
+
+	for queue.Len() > 0 {
+		fmt.Println(queue.Pop().name)
+	}
+
+	// Output:
+	// false true
+	// deploy
+	// build
+	// test
+}
+
+func ExampleMap() {
+	jobs := heaps.NewMap[int, int]()
+
+	jobs.Set(41, 3).Set(42, 5).Set(43, 2)
+
+	// bump priority of job 42
+	jobs.Set(42, 1)
+	jobs.Delete(43)
+	fmt.Println(jobs.Get(42))
+	fmt.Println(jobs.Contains(43))
+
+	for jobs.Len() > 0 {
+		fmt.Println(jobs.PopMin())
+	}
+
+	// Output:
+	// 1 true
+	// false
+	// 42 1
+	// 41 3
+}
+
+func ExampleDAry() {
+	h := heaps.NewDAry(4, 8, 3, 5, 1, 9, 2)
+	h.Push(4).Push(7)
+
+	for h.Len() > 0 {
+		fmt.Printf("%d ", h.Pop())
+	}
+
+	// Output:
+	// 1 2 3 4 5 7 8 9
+}
+
+func ExamplePairing_Meld() {
+	h1 := heaps.NewPairing(5, 1, 3)
+	h2 := heaps.NewPairing(4, 2, 6)
+
+	h1.Meld(h2)
+	fmt.Println("melded:", h1.Len(), h2.Len())
+
+	for h1.Len() > 0 {
+		fmt.Printf("%d ", h1.Pop())
+	}
+
+	// Output:
+	// melded: 6 0
+	// 1 2 3 4 5 6
+}
+
+func ExampleFibonacci_Update() {
+	h := heaps.NewFibonacci(5, 3)
+	n := h.PushHandle(8)
+	h.Push(6)
+
+	h.Update(n, 1)
+	fmt.Println("minimum:", h.Top())
+
+	h.Remove(n)
+	fmt.Println("valid:", n.Valid())
+
+	for h.Len() > 0 {
+		fmt.Printf("%d ", h.Pop())
+	}
+
+	// Output:
+	// minimum: 1
+	// valid: false
+	// 3 5 6
+}
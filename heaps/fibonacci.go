@@ -0,0 +1,300 @@
+package heaps
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Fibonacci is a [Fibonacci heap], a collection of heap-ordered trees.
+// Pushing an element, melding two heaps, and decreasing an element all cost O(1) amortized,
+// while Pop costs O(log n) amortized, which makes it suitable for algorithms like Dijkstra's
+// on dense graphs, where decreases far outnumber pops.
+// The constant factors are larger than other heaps, so it is rarely faster in practice.
+//
+// Elements to be decreased or removed later are pushed by [Fibonacci.PushHandle], which returns a [FibonacciHandle],
+// just like the [Handle] of an [Indexed] heap.
+//
+// A Fibonacci heap is not safe for concurrent use by multiple goroutines.
+//
+// [Fibonacci heap]: https://en.wikipedia.org/wiki/Fibonacci_heap
+type Fibonacci[E any] struct {
+	min  *FibonacciHandle[E] // in the circular list of roots
+	size int
+	less func(x, y E) bool
+
+	// shared by the handles pushed into the heap, see [fibonacciOwner]
+	owner *fibonacciOwner
+
+	// buffers reused by Pop
+	roots   []*FibonacciHandle[E]
+	degrees []*FibonacciHandle[E]
+}
+
+// FibonacciHandle refers to an element in a [Fibonacci] heap.
+// It is valid until the element is popped or removed from the heap.
+type FibonacciHandle[E any] struct {
+	value E
+	owner *fibonacciOwner
+
+	parent, child *FibonacciHandle[E]
+	left, right   *FibonacciHandle[E] // siblings in a circular list, nil if the element is not in the heap any more
+	degree        int                 // number of children
+	marked        bool                // whether a child has been cut since it became a child
+}
+
+// fibonacciOwner identifies the heap a handle belongs to.
+// Melding a heap forwards its owner to the one of the heap melded into,
+// so the handles need not be walked through, and Meld still costs O(1).
+type fibonacciOwner struct {
+	into *fibonacciOwner // nil if the heap is not melded into another one
+}
+
+// root follows the forwarding to the owner of the heap holding the elements,
+// and halves the path along the way.
+func (o *fibonacciOwner) root() *fibonacciOwner {
+	for o.into != nil {
+		if o.into.into != nil {
+			o.into = o.into.into
+		}
+		o = o.into
+	}
+	return o
+}
+
+// Value returns the element referred by the handle.
+// It is still available after the element is popped or removed.
+func (n *FibonacciHandle[E]) Value() E { return n.value }
+
+// Valid reports whether the element is still in the heap.
+func (n *FibonacciHandle[E]) Valid() bool { return n.left != nil }
+
+// NewFibonacci creates a new Fibonacci min-heap for ordered element types.
+// The initial values are optional.
+func NewFibonacci[E cmp.Ordered](values ...E) *Fibonacci[E] {
+	return NewFibonacciFunc(func(x, y E) bool { return x < y }, values...)
+}
+
+// NewFibonacciFunc creates a new Fibonacci min-heap for any type.
+// The initial values are optional.
+func NewFibonacciFunc[E any](less func(x, y E) bool, values ...E) *Fibonacci[E] {
+	h := &Fibonacci[E]{less: less, owner: &fibonacciOwner{}}
+	for _, v := range values {
+		h.PushHandle(v)
+	}
+	return h
+}
+
+// Len returns number of elements in the heap.
+func (h *Fibonacci[E]) Len() int { return h.size }
+
+// Push pushes the element x onto the heap.
+// The complexity is O(1).
+func (h *Fibonacci[E]) Push(x E) *Fibonacci[E] {
+	h.PushHandle(x)
+	return h
+}
+
+// PushHandle is like [Fibonacci.Push], but returns the handle of x,
+// which could be decreased or removed later.
+// The complexity is O(1).
+func (h *Fibonacci[E]) PushHandle(x E) *FibonacciHandle[E] {
+	n := &FibonacciHandle[E]{value: x, owner: h.owner}
+	n.left, n.right = n, n
+	h.addRoots(n)
+	h.size++
+	return n
+}
+
+// Top returns the first element from the heap.
+// The complexity is O(1).
+func (h *Fibonacci[E]) Top() E {
+	return h.min.value
+}
+
+// TopHandle returns handle of the first element from the heap.
+// The complexity is O(1).
+func (h *Fibonacci[E]) TopHandle() *FibonacciHandle[E] {
+	return h.min
+}
+
+// Pop removes and returns the first element from the heap.
+// The complexity is O(log n) amortized where n = h.Len().
+func (h *Fibonacci[E]) Pop() E {
+	z := h.min
+
+	// children of the minimum become roots
+	if z.child != nil {
+		for c := z.child; c.parent != nil; c = c.right {
+			c.parent = nil
+		}
+		splice(z, z.child)
+		z.child = nil
+	}
+
+	if z.right == z {
+		h.min = nil
+	} else {
+		z.left.right, z.right.left = z.right, z.left
+		h.min = z.right
+		h.consolidate()
+	}
+
+	z.left, z.right = nil, nil
+	h.size--
+	return z.value
+}
+
+// Update replaces the element referred by the handle with x, and restores the heap order.
+// Unlike [Indexed.Update], x must not be greater than the original element, i.e., it could only be decreased.
+// The complexity is O(1) amortized.
+// It panics if the handle is not valid in the heap, or x is greater than the original element.
+func (h *Fibonacci[E]) Update(n *FibonacciHandle[E], x E) {
+	h.check(n)
+	if h.less(n.value, x) {
+		panic(fmt.Errorf("heaps: cannot decrease %v to a greater value %v", n.value, x))
+	}
+
+	n.value = x
+	if p := n.parent; p != nil && h.less(n.value, p.value) {
+		h.cut(n)
+	}
+	if h.less(n.value, h.min.value) {
+		h.min = n
+	}
+}
+
+// Remove removes and returns the element referred by the handle, the handle becomes invalid.
+// The complexity is O(log n) amortized where n = h.Len().
+// It panics if the handle is not valid in the heap.
+func (h *Fibonacci[E]) Remove(n *FibonacciHandle[E]) E {
+	h.check(n)
+	if n.parent != nil {
+		h.cut(n)
+	}
+
+	// n is a root now, pop it as if it were the minimum
+	h.min = n
+	return h.Pop()
+}
+
+// Meld moves all elements in h2 to h, and leaves h2 empty.
+// Both heaps are expected to be ordered by the same less function.
+// The handles of the elements in h2 are valid in h, instead of h2, from now on.
+// The complexity is O(1).
+func (h *Fibonacci[E]) Meld(h2 *Fibonacci[E]) *Fibonacci[E] {
+	if h2 == h || h2.min == nil {
+		return h
+	}
+
+	h.addRoots(h2.min)
+	h.size += h2.size
+	h2.owner.into = h.owner
+	h2.min, h2.size, h2.owner = nil, 0, &fibonacciOwner{}
+	return h
+}
+
+func (h *Fibonacci[E]) check(n *FibonacciHandle[E]) {
+	if !n.Valid() || n.owner.root() != h.owner {
+		panic(fmt.Errorf("heaps: handle of %v is not valid in the heap", n.value))
+	}
+}
+
+// addRoots adds the circular list containing n to the roots.
+func (h *Fibonacci[E]) addRoots(n *FibonacciHandle[E]) {
+	if h.min == nil {
+		h.min = n
+		return
+	}
+
+	splice(h.min, n)
+	if h.less(n.value, h.min.value) {
+		h.min = n
+	}
+}
+
+// consolidate links the roots with the same degree, until all the roots have distinct degrees,
+// and finds the new minimum.
+func (h *Fibonacci[E]) consolidate() {
+	h.roots = h.roots[:0]
+	for n := h.min; ; {
+		h.roots = append(h.roots, n)
+		if n = n.right; n == h.min {
+			break
+		}
+	}
+
+	for _, x := range h.roots {
+		d := x.degree
+		for d < len(h.degrees) && h.degrees[d] != nil {
+			y := h.degrees[d]
+			if h.less(y.value, x.value) {
+				x, y = y, x
+			}
+			h.link(y, x)
+			h.degrees[d] = nil
+			d++
+		}
+		for d >= len(h.degrees) {
+			h.degrees = append(h.degrees, nil)
+		}
+		h.degrees[d] = x
+	}
+
+	// rebuild the roots from the ones left
+	h.min = nil
+	for d, n := range h.degrees {
+		if n != nil {
+			n.left, n.right = n, n
+			h.addRoots(n)
+			h.degrees[d] = nil
+		}
+	}
+	clear(h.roots)
+}
+
+// link makes y a child of x, y is not taken care of in the list of roots.
+func (h *Fibonacci[E]) link(y, x *FibonacciHandle[E]) {
+	y.parent = x
+	y.marked = false
+	y.left, y.right = y, y
+	if x.child == nil {
+		x.child = y
+	} else {
+		splice(x.child, y)
+	}
+	x.degree++
+}
+
+// cut moves n from its parent to the roots,
+// and goes on cutting the ancestors which have already lost a child.
+func (h *Fibonacci[E]) cut(n *FibonacciHandle[E]) {
+	for p := n.parent; p != nil; n, p = p, p.parent {
+		if n.right == n {
+			p.child = nil
+		} else {
+			n.left.right, n.right.left = n.right, n.left
+			if p.child == n {
+				p.child = n.right
+			}
+		}
+		p.degree--
+
+		n.parent = nil
+		n.marked = false
+		n.left, n.right = n, n
+		splice(h.min, n)
+
+		if !p.marked {
+			// roots are never marked
+			p.marked = p.parent != nil
+			return
+		}
+	}
+}
+
+// splice concatenates two circular lists containing a and b.
+func splice[E any](a, b *FibonacciHandle[E]) {
+	aRight, bLeft := a.right, b.left
+	a.right, b.left = b, a
+	bLeft.right, aRight.left = aRight, bLeft
+}
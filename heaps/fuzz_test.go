@@ -0,0 +1,468 @@
+package heaps_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/houz42/abstract/heaps"
+	"github.com/houz42/abstract/internal/modeltest"
+)
+
+func FuzzHeap(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		const values = 64
+
+		h := heaps.New[int]()
+		model := &modeltest.Sorted[int]{Duplicates: true}
+
+		ops := []modeltest.Op{
+			{Name: "Push", Run: func(arg int) error {
+				h.Push(arg % values)
+				model.Set(arg % values)
+				return nil
+			}},
+			{Name: "Pop", Run: func(int) error {
+				if h.Len() == 0 {
+					return nil
+				}
+				got := h.Pop()
+				want := model.Values[0]
+				model.Values = model.Values[1:]
+				return modeltest.Equal("Pop()", got, want)
+			}},
+			{Name: "RemoveAt", Run: func(arg int) error {
+				if h.Len() == 0 {
+					return nil
+				}
+				i := arg % h.Len()
+				v := h.RemoveAt(i)
+				if _, ok := model.Index(v); !ok {
+					return fmt.Errorf("RemoveAt(%d): removed %d which is not in the heap", i, v)
+				}
+				model.Unset(v)
+				return nil
+			}},
+			{Name: "Clone", Run: func(int) error {
+				h = h.Clone()
+				return nil
+			}},
+			{Name: "Reverse", Run: func(int) error {
+				h = h.Reverse().Reverse()
+				return nil
+			}},
+			{Name: "Merge", Run: func(int) error {
+				if h.Len() > 256 {
+					return nil
+				}
+				h.Merge(h.Clone())
+				for _, v := range slices.Clone(model.Values) {
+					model.Set(v)
+				}
+				return nil
+			}},
+			{Name: "MergeAll", Run: func(arg int) error {
+				if h.Len() > 256 {
+					return nil
+				}
+				extra := make([]int, arg%16)
+				for i := range extra {
+					extra[i] = (arg + i*7) % values
+				}
+				// merging a copy of itself doubles the size, so larger heaps are re-heapified
+				h.MergeAll(h, heaps.New(extra...))
+				for _, v := range append(slices.Clone(model.Values), extra...) {
+					model.Set(v)
+				}
+				return nil
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", h.Len(), len(model.Values)); err != nil {
+				return err
+			}
+			if h.Len() == 0 {
+				return nil
+			}
+			return modeltest.Equal("Top()", h.Top(), model.Values[0])
+		})
+	})
+}
+
+func FuzzIndexed(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		const values = 64
+
+		h := heaps.NewIndexed[int]()
+
+		// handles in the heap with their values, in the order of being pushed
+		var handles []*heaps.Handle[int]
+		var model []int
+
+		forget := func(handle *heaps.Handle[int]) {
+			i := slices.Index(handles, handle)
+			handles = slices.Delete(handles, i, i+1)
+			model = slices.Delete(model, i, i+1)
+		}
+
+		ops := []modeltest.Op{
+			{Name: "Push", Run: func(arg int) error {
+				handles = append(handles, h.Push(arg%values))
+				model = append(model, arg%values)
+				return nil
+			}},
+			{Name: "Pop", Run: func(int) error {
+				if h.Len() == 0 {
+					return nil
+				}
+				top := h.TopHandle()
+				got := h.Pop()
+				if top.Valid() {
+					return fmt.Errorf("Pop(): handle of %d is still valid", got)
+				}
+				forget(top)
+				return modeltest.Equal("Pop()", got, top.Value())
+			}},
+			{Name: "Update", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				i := arg % len(model)
+				h.Update(handles[i], arg/256%values)
+				model[i] = arg / 256 % values
+				return nil
+			}},
+			{Name: "Remove", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				handle := handles[arg%len(model)]
+				got := h.Remove(handle)
+				forget(handle)
+				if handle.Valid() {
+					return fmt.Errorf("Remove(): handle of %d is still valid", got)
+				}
+				return modeltest.Equal("Remove()", got, handle.Value())
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", h.Len(), len(model)); err != nil {
+				return err
+			}
+
+			for i, handle := range handles {
+				if !handle.Valid() {
+					return fmt.Errorf("handle of %d is not valid", model[i])
+				}
+				if err := modeltest.Equal("Value()", handle.Value(), model[i]); err != nil {
+					return err
+				}
+			}
+			if h.Len() == 0 {
+				return nil
+			}
+			return modeltest.Equal("Top()", h.Top(), slices.Min(model))
+		})
+	})
+}
+
+func FuzzMap(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		const (
+			keys   = 64
+			values = 64
+		)
+
+		m := heaps.NewMap[int, int]()
+		model := make(map[int]int)
+
+		ops := []modeltest.Op{
+			{Name: "Set", Run: func(arg int) error {
+				m.Set(arg%keys, arg/256%values)
+				model[arg%keys] = arg / 256 % values
+				return nil
+			}},
+			{Name: "Delete", Run: func(arg int) error {
+				m.Delete(arg % keys)
+				delete(model, arg%keys)
+				return nil
+			}},
+			{Name: "PopMin", Run: func(int) error {
+				if m.Len() == 0 {
+					return nil
+				}
+				k, p := m.PopMin()
+				for _, v := range model {
+					if v < p {
+						return fmt.Errorf("PopMin(): got priority %d, but %d is lower", p, v)
+					}
+				}
+				if err := modeltest.Equal(fmt.Sprintf("PopMin(): priority of %d", k), p, model[k]); err != nil {
+					return err
+				}
+				delete(model, k)
+				return nil
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", m.Len(), len(model)); err != nil {
+				return err
+			}
+			for k := 0; k < keys; k++ {
+				want, ok := model[k]
+				if err := modeltest.Equal(fmt.Sprintf("Contains(%d)", k), m.Contains(k), ok); err != nil {
+					return err
+				}
+				got, _ := m.Get(k)
+				if err := modeltest.Equal(fmt.Sprintf("Get(%d)", k), got, want); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func FuzzDAry(f *testing.F) {
+	for i, seed := range modeltest.Seeds(500) {
+		f.Add(uint8(i), seed)
+	}
+
+	f.Fuzz(func(t *testing.T, d uint8, data []byte) {
+		const values = 64
+
+		h := heaps.NewDAry[int](2 + int(d%7))
+		model := &modeltest.Sorted[int]{Duplicates: true}
+
+		ops := []modeltest.Op{
+			pushOp(&h, model, values),
+			popOp(&h, model),
+			{Name: "RemoveAt", Run: func(arg int) error {
+				if h.Len() == 0 {
+					return nil
+				}
+				i := arg % h.Len()
+				v := h.RemoveAt(i)
+				if _, ok := model.Index(v); !ok {
+					return fmt.Errorf("RemoveAt(%d): removed %d which is not in the heap", i, v)
+				}
+				model.Unset(v)
+				return nil
+			}},
+			{Name: "Clone", Run: func(int) error {
+				h = h.Clone()
+				return nil
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error { return checkTop(h, model) })
+	})
+}
+
+func FuzzPairing(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		const values = 64
+
+		h := heaps.NewPairing[int]()
+		model := &modeltest.Sorted[int]{Duplicates: true}
+
+		ops := []modeltest.Op{
+			pushOp(&h, model, values),
+			popOp(&h, model),
+			{Name: "Meld", Run: func(arg int) error {
+				h2 := heaps.NewPairing[int]()
+				for i := 0; i < arg%8; i++ {
+					h2.Push((arg + i*7) % values)
+					model.Set((arg + i*7) % values)
+				}
+				h.Meld(h2)
+				return modeltest.Equal("Len() of the melded heap", h2.Len(), 0)
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error { return checkTop(h, model) })
+	})
+}
+
+func FuzzFibonacci(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		const values = 64
+
+		h := heaps.NewFibonacci[int]()
+
+		// handles in the heap with their values, in the order of being pushed
+		var handles []*heaps.FibonacciHandle[int]
+		var model []int
+
+		forget := func(handle *heaps.FibonacciHandle[int]) error {
+			i := slices.Index(handles, handle)
+			if i < 0 {
+				return fmt.Errorf("handle of %d is not pushed", handle.Value())
+			}
+			if handle.Valid() {
+				return fmt.Errorf("handle of %d is still valid", handle.Value())
+			}
+			handles = slices.Delete(handles, i, i+1)
+			model = slices.Delete(model, i, i+1)
+			return nil
+		}
+
+		// panics reports an error unless f panics
+		panics := func(what string, f func()) (err error) {
+			defer func() {
+				if recover() == nil {
+					err = fmt.Errorf("%s: expected to panic", what)
+				}
+			}()
+			f()
+			return nil
+		}
+
+		ops := []modeltest.Op{
+			{Name: "PushHandle", Run: func(arg int) error {
+				handles = append(handles, h.PushHandle(arg%values))
+				model = append(model, arg%values)
+				return nil
+			}},
+			{Name: "Pop", Run: func(int) error {
+				if h.Len() == 0 {
+					return nil
+				}
+				want := slices.Min(model)
+				top := h.TopHandle()
+				if err := modeltest.Equal("TopHandle().Value()", top.Value(), want); err != nil {
+					return err
+				}
+				if err := modeltest.Equal("Pop()", h.Pop(), want); err != nil {
+					return err
+				}
+				return forget(top)
+			}},
+			{Name: "Update", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				i := arg % len(model)
+				h.Update(handles[i], model[i]-arg/256%8)
+				model[i] -= arg / 256 % 8
+				return nil
+			}},
+			{Name: "Remove", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				i := arg % len(model)
+				handle, want := handles[i], model[i]
+				if err := modeltest.Equal("Remove()", h.Remove(handle), want); err != nil {
+					return err
+				}
+				return forget(handle)
+			}},
+			{Name: "Meld", Run: func(arg int) error {
+				h2 := heaps.NewFibonacci[int]()
+				for i := 0; i < arg%8; i++ {
+					handles = append(handles, h2.PushHandle((arg+i*7)%values))
+					model = append(model, (arg+i*7)%values)
+				}
+				h.Meld(h2)
+				if err := modeltest.Equal("Len() of the melded heap", h2.Len(), 0); err != nil {
+					return err
+				}
+				if len(handles) == 0 {
+					return nil
+				}
+				// handles are moved to h along with the elements
+				return panics("Remove() of the melded heap", func() { h2.Remove(handles[len(handles)-1]) })
+			}},
+			{Name: "Foreign", Run: func(arg int) error {
+				other := heaps.NewFibonacci(arg % values)
+				foreign := other.PushHandle(arg % values)
+				if err := panics("Remove() of a foreign handle", func() { h.Remove(foreign) }); err != nil {
+					return err
+				}
+				if err := panics("Update() of a foreign handle", func() { h.Update(foreign, foreign.Value()) }); err != nil {
+					return err
+				}
+				if len(handles) > 0 {
+					handle := handles[arg%len(handles)]
+					if err := panics("Remove() from another heap", func() { other.Remove(handle) }); err != nil {
+						return err
+					}
+				}
+				return modeltest.Equal("Len() of the other heap", other.Len(), 2)
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", h.Len(), len(model)); err != nil {
+				return err
+			}
+			for i, handle := range handles {
+				if !handle.Valid() {
+					return fmt.Errorf("handle of %d is not valid", model[i])
+				}
+				if err := modeltest.Equal("Value()", handle.Value(), model[i]); err != nil {
+					return err
+				}
+			}
+			if h.Len() == 0 {
+				return nil
+			}
+			return modeltest.Equal("Top()", h.Top(), slices.Min(model))
+		})
+	})
+}
+
+// pushOp and popOp work on any heap, h is referred by pointer in case it is replaced during the test.
+func pushOp[H heaps.Interface[int, H]](h *H, model *modeltest.Sorted[int], values int) modeltest.Op {
+	return modeltest.Op{Name: "Push", Run: func(arg int) error {
+		(*h).Push(arg % values)
+		model.Set(arg % values)
+		return nil
+	}}
+}
+
+func popOp[H heaps.Interface[int, H]](h *H, model *modeltest.Sorted[int]) modeltest.Op {
+	return modeltest.Op{Name: "Pop", Run: func(int) error {
+		if (*h).Len() == 0 {
+			return nil
+		}
+		got := (*h).Pop()
+		want := model.Values[0]
+		model.Values = model.Values[1:]
+		return modeltest.Equal("Pop()", got, want)
+	}}
+}
+
+func checkTop[H heaps.Interface[int, H]](h H, model *modeltest.Sorted[int]) error {
+	if err := modeltest.Equal("Len()", h.Len(), len(model.Values)); err != nil {
+		return err
+	}
+	if h.Len() == 0 {
+		return nil
+	}
+	return modeltest.Equal("Top()", h.Top(), model.Values[0])
+}
@@ -1,9 +1,42 @@
-// Package heaps provides a heap implementation for any type.
+// Package heaps provides heap implementations for any type.
+//
+// [Heap] is a binary heap fits most of the workloads.
+// Alternatives are provided behind the common [Interface] to be chosen per workload:
+// [DAry] for better cache locality, [Pairing] for cheap melding,
+// and [Fibonacci] for cheap decreasing of the elements.
 package heaps
 
 import (
 	"cmp"
-	"container/heap"
+	"math/bits"
+	"slices"
+)
+
+// binary is the arity of Heap.
+const binary = 2
+
+// Interface is implemented by all the heaps in this package, with element type E.
+// H is the type of the heap itself, which is returned by Push for chaining.
+//
+// A generic function working on any of the heaps could be written as:
+//
+//	func drain[E any, H heaps.Interface[E, H]](h H) []E
+type Interface[E, H any] interface {
+	// Len returns number of elements in the heap.
+	Len() int
+	// Push pushes the element x onto the heap.
+	Push(x E) H
+	// Pop removes and returns the first element from the heap.
+	Pop() E
+	// Top returns the first element from the heap.
+	Top() E
+}
+
+var (
+	_ Interface[int, *Heap[int]]      = (*Heap[int])(nil)
+	_ Interface[int, *DAry[int]]      = (*DAry[int])(nil)
+	_ Interface[int, *Pairing[int]]   = (*Pairing[int])(nil)
+	_ Interface[int, *Fibonacci[int]] = (*Fibonacci[int])(nil)
 )
 
 // Heap is a tree with the property that each node is the minimum-valued (or maximum if reversed)
@@ -17,6 +50,7 @@ import (
 // pass in a less method which orders the elements by their priorities,
 // so [Push] adds items while [Pop] removes the highest-priority item from the queue.
 // See the example for more details.
+// If the queued items need to be reprioritized or removed, use an [Indexed] heap instead.
 //
 // A Heap is not safe for concurrent use by multiple goroutines.
 type Heap[E any] struct {
@@ -32,12 +66,11 @@ func New[E cmp.Ordered](values ...E) *Heap[E] {
 // NewFunc creates a new min-heap for any type.
 // The initial values are optional.
 func NewFunc[E any](less func(x, y E) bool, values ...E) *Heap[E] {
-	impl := &heapImpl[E]{
+	heapify(values, binary, less)
+	return &Heap[E]{impl: &heapImpl[E]{
 		values: values,
 		less:   less,
-	}
-	heap.Init(impl)
-	return &Heap[E]{impl: impl}
+	}}
 }
 
 // Reverse returns a new Heap in which the elements will be pop out in reserved sequence to the original one.
@@ -52,7 +85,7 @@ func (h *Heap[E]) Reverse() *Heap[E] {
 			less:   func(x, y E) bool { return h.impl.less(y, x) },
 		},
 	}
-	heap.Init(r.impl)
+	heapify(r.impl.values, binary, r.impl.less)
 
 	return r
 }
@@ -63,7 +96,8 @@ func (h *Heap[E]) Len() int { return len(h.impl.values) }
 // Push pushes the element x onto the heap.
 // The complexity is O(log n) where n = h.Len().
 func (h *Heap[E]) Push(x E) *Heap[E] {
-	heap.Push(h.impl, x)
+	h.impl.values = append(h.impl.values, x)
+	up(h.impl.values, len(h.impl.values)-1, binary, h.impl.less)
 	return h
 }
 
@@ -71,8 +105,7 @@ func (h *Heap[E]) Push(x E) *Heap[E] {
 // The complexity is O(log n) where n = h.Len().
 // Pop is equivalent to [Remove](h).
 func (h *Heap[E]) Pop() E {
-	v := heap.Pop(h.impl)
-	return v.(E)
+	return h.RemoveAt(0)
 }
 
 // Top returns the first element from the heap.
@@ -84,13 +117,14 @@ func (h *Heap[E]) Top() E {
 // RemoveAt removes and returns the element at index i from the heap.
 // The complexity is O(log n) where n = h.Len().
 func (h *Heap[E]) RemoveAt(i int) E {
-	v := heap.Remove(h.impl, i)
-	return v.(E)
+	var x E
+	h.impl.values, x = remove(h.impl.values, i, binary, h.impl.less)
+	return x
 }
 
 // Clone returns a new heap which contains same elements in h.
 func (h *Heap[E]) Clone() *Heap[E] {
-	values := make([]E, 0, h.Len())
+	values := make([]E, h.Len())
 	copy(values, h.impl.values)
 
 	return &Heap[E]{impl: &heapImpl[E]{
@@ -101,14 +135,41 @@ func (h *Heap[E]) Clone() *Heap[E] {
 
 // Merge all elements in h2 to h.
 // Elements in h2 will be kept untouched.
+// The complexity is O(min(m log(n+m), n+m)) where n = h.Len() and m = h2.Len().
 func (h *Heap[E]) Merge(h2 *Heap[E]) *Heap[E] {
-	for _, v := range h2.impl.values {
-		h.Push(v)
+	return h.MergeAll(h2)
+}
+
+// MergeAll merges all elements in the heaps to h.
+// Elements in the heaps will be kept untouched.
+// The complexity is O(min(m log(n+m), n+m)) where n = h.Len() and m is the total number of elements in the heaps.
+func (h *Heap[E]) MergeAll(heaps ...*Heap[E]) *Heap[E] {
+	n, m := h.Len(), 0
+	for _, h2 := range heaps {
+		m += h2.Len()
+	}
+
+	values := slices.Grow(h.impl.values, m)
+	for _, h2 := range heaps {
+		values = append(values, h2.impl.values...)
+	}
+	h.impl.values = values
+
+	// sifting up each new element costs up to log(n+m) comparisons,
+	// while heapifying all of them costs up to 2(n+m)
+	if m*bits.Len(uint(n+m)) > 2*(n+m) {
+		heapify(values, binary, h.impl.less)
+	} else {
+		for i := n; i < len(values); i++ {
+			up(values, i, binary, h.impl.less)
+		}
 	}
 
 	return h
 }
 
+// heapImpl implements container/heap.Interface, for the heaps keeping track of positions of their elements on swaps.
+// Heap itself sifts the values directly, to avoid boxing the elements into interfaces.
 type heapImpl[E any] struct {
 	values []E
 	less   func(x, y E) bool
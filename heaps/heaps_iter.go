@@ -2,7 +2,10 @@
 
 package heaps
 
-import "iter"
+import (
+	"cmp"
+	"iter"
+)
 
 // Drain returns an iterator that pops elements from the heap in the order of the heap.
 // It is intentionally named "Drain" to distinguish it from other types' "All" methods,
@@ -18,3 +21,56 @@ func (h *Heap[E]) Drain() iter.Seq2[int, E] {
 		}
 	}
 }
+
+// MergeSorted returns an iterator that merges the sorted sources into one sorted sequence, a.k.a. a k-way merge.
+// Equal elements are yielded in the order of their sources.
+//
+// The sources are consumed lazily, only one element is held for each source at a time,
+// so they could be large or even infinite.
+// The complexity to yield each element is O(log k) where k = len(sources).
+func MergeSorted[E cmp.Ordered](sources ...iter.Seq[E]) iter.Seq[E] {
+	return MergeSortedFunc(func(x, y E) bool { return x < y }, sources...)
+}
+
+// MergeSortedFunc is like [MergeSorted], but the sources are sorted by the less function.
+func MergeSortedFunc[E any](less func(x, y E) bool, sources ...iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		lessHead := func(x, y head[E]) bool {
+			if less(x.value, y.value) {
+				return true
+			}
+			return !less(y.value, x.value) && x.source < y.source
+		}
+
+		heads := make([]head[E], 0, len(sources))
+		for i, seq := range sources {
+			next, stop := iter.Pull(seq)
+			defer stop()
+
+			if v, ok := next(); ok {
+				heads = append(heads, head[E]{value: v, source: i, next: next})
+			}
+		}
+		heapify(heads, binary, lessHead)
+
+		for len(heads) > 0 {
+			if !yield(heads[0].value) {
+				return
+			}
+
+			if v, ok := heads[0].next(); ok {
+				heads[0].value = v
+				down(heads, 0, binary, lessHead)
+			} else {
+				heads, _ = remove(heads, 0, binary, lessHead)
+			}
+		}
+	}
+}
+
+// head is the next element of a source to be merged.
+type head[E any] struct {
+	value  E
+	source int
+	next   func() (E, bool)
+}
@@ -0,0 +1,134 @@
+package heaps
+
+import (
+	"cmp"
+	"container/heap"
+	"fmt"
+)
+
+// Indexed is a min-heap whose elements are addressed by stable handles, a.k.a. an indexed priority queue.
+// Pushing an element returns its [Handle], which keeps track of the element's position in the heap,
+// so the element could be updated or removed later in O(log n) time,
+// e.g., to decrease the distance of a vertex in Dijkstra's algorithm.
+//
+// An Indexed heap is not safe for concurrent use by multiple goroutines.
+type Indexed[E any] struct {
+	impl *indexedImpl[E]
+}
+
+// Handle refers to an element in an [Indexed] heap.
+// It is valid until the element is popped or removed from the heap.
+type Handle[E any] struct {
+	value E
+	index int // -1 if the element is not in the heap any more
+}
+
+// Value returns the element referred by the handle.
+// It is still available after the element is popped or removed.
+func (h *Handle[E]) Value() E { return h.value }
+
+// Valid reports whether the element is still in the heap.
+func (h *Handle[E]) Valid() bool { return h.index >= 0 }
+
+// NewIndexed creates a new Indexed min-heap for ordered element types.
+func NewIndexed[E cmp.Ordered]() *Indexed[E] {
+	return NewIndexedFunc(func(x, y E) bool { return x < y })
+}
+
+// NewIndexedFunc creates a new Indexed min-heap for any type.
+func NewIndexedFunc[E any](less func(x, y E) bool) *Indexed[E] {
+	return &Indexed[E]{impl: &indexedImpl[E]{less: less}}
+}
+
+// Len returns number of elements in the heap.
+func (h *Indexed[E]) Len() int { return len(h.impl.handles) }
+
+// Push pushes the element x onto the heap, and returns its handle.
+// The complexity is O(log n) where n = h.Len().
+func (h *Indexed[E]) Push(x E) *Handle[E] {
+	handle := &Handle[E]{value: x}
+	heap.Push(h.impl, handle)
+	return handle
+}
+
+// Pop removes and returns the first element from the heap, its handle becomes invalid.
+// The complexity is O(log n) where n = h.Len().
+func (h *Indexed[E]) Pop() E {
+	return heap.Pop(h.impl).(*Handle[E]).value
+}
+
+// Top returns the first element from the heap.
+// The complexity is O(1).
+func (h *Indexed[E]) Top() E {
+	return h.impl.handles[0].value
+}
+
+// TopHandle returns handle of the first element from the heap.
+// The complexity is O(1).
+func (h *Indexed[E]) TopHandle() *Handle[E] {
+	return h.impl.handles[0]
+}
+
+// Update replaces the element referred by the handle with x, and restores the heap order.
+// The complexity is O(log n) where n = h.Len().
+// It panics if the handle is not valid in the heap.
+func (h *Indexed[E]) Update(handle *Handle[E], x E) {
+	h.check(handle)
+	handle.value = x
+	heap.Fix(h.impl, handle.index)
+}
+
+// Fix restores the heap order after the element referred by the handle has changed in place,
+// e.g., if E is a pointer type and the pointed value is modified.
+// The complexity is O(log n) where n = h.Len().
+// It panics if the handle is not valid in the heap.
+func (h *Indexed[E]) Fix(handle *Handle[E]) {
+	h.check(handle)
+	heap.Fix(h.impl, handle.index)
+}
+
+// Remove removes and returns the element referred by the handle, the handle becomes invalid.
+// The complexity is O(log n) where n = h.Len().
+// It panics if the handle is not valid in the heap.
+func (h *Indexed[E]) Remove(handle *Handle[E]) E {
+	h.check(handle)
+	return heap.Remove(h.impl, handle.index).(*Handle[E]).value
+}
+
+func (h *Indexed[E]) check(handle *Handle[E]) {
+	if handle.index < 0 || handle.index >= h.Len() || h.impl.handles[handle.index] != handle {
+		panic(fmt.Errorf("heaps: handle of %v is not valid in the heap", handle.value))
+	}
+}
+
+type indexedImpl[E any] struct {
+	handles []*Handle[E]
+	less    func(x, y E) bool
+}
+
+func (h *indexedImpl[E]) Len() int { return len(h.handles) }
+func (h *indexedImpl[E]) Less(i, j int) bool {
+	return h.less(h.handles[i].value, h.handles[j].value)
+}
+
+func (h *indexedImpl[E]) Swap(i, j int) {
+	h.handles[i], h.handles[j] = h.handles[j], h.handles[i]
+	h.handles[i].index = i
+	h.handles[j].index = j
+}
+
+func (h *indexedImpl[E]) Push(x any) {
+	handle := x.(*Handle[E])
+	handle.index = len(h.handles)
+	h.handles = append(h.handles, handle)
+}
+
+func (h *indexedImpl[E]) Pop() any {
+	old := h.handles
+	n := len(old)
+	handle := old[n-1]
+	old[n-1] = nil
+	handle.index = -1
+	h.handles = old[0 : n-1]
+	return handle
+}
@@ -0,0 +1,118 @@
+package heaps
+
+import (
+	"cmp"
+	"container/heap"
+)
+
+// Map is a min-heap of keys ordered by their priorities, a.k.a. a keyed priority queue.
+// Each key is in the heap at most once, and its priority could be looked up, changed or deleted by the key,
+// e.g., to bump priority of a job by its ID.
+//
+// Looking up a key costs O(1), while changing or deleting one costs O(log n).
+//
+// A Map is not safe for concurrent use by multiple goroutines.
+type Map[K comparable, P any] struct {
+	impl *mapImpl[K, P]
+}
+
+// NewMap creates a new Map with ordered priorities, the key with the lowest priority is popped first.
+func NewMap[K comparable, P cmp.Ordered]() *Map[K, P] {
+	return NewMapFunc[K](func(x, y P) bool { return x < y })
+}
+
+// NewMapFunc creates a new Map with priorities of any type, ordered by the less function.
+func NewMapFunc[K comparable, P any](less func(x, y P) bool) *Map[K, P] {
+	return &Map[K, P]{impl: &mapImpl[K, P]{
+		heapImpl: heapImpl[entry[K, P]]{
+			less: func(x, y entry[K, P]) bool { return less(x.prio, y.prio) },
+		},
+		index: make(map[K]int),
+	}}
+}
+
+// Len returns number of keys in the Map.
+func (m *Map[K, P]) Len() int { return len(m.impl.values) }
+
+// Set puts the key with its priority into the Map.
+// If the key is already in, its priority is changed.
+// The complexity is O(log n) where n = m.Len().
+func (m *Map[K, P]) Set(key K, prio P) *Map[K, P] {
+	if i, ok := m.impl.index[key]; ok {
+		m.impl.values[i].prio = prio
+		heap.Fix(m.impl, i)
+		return m
+	}
+
+	heap.Push(m.impl, entry[K, P]{key: key, prio: prio})
+	return m
+}
+
+// Get returns priority of the key and true if it is in the Map,
+// otherwise zero value of type P and false.
+// The complexity is O(1).
+func (m *Map[K, P]) Get(key K) (P, bool) {
+	if i, ok := m.impl.index[key]; ok {
+		return m.impl.values[i].prio, true
+	}
+	var p P
+	return p, false
+}
+
+// Contains reports whether the key is in the Map.
+func (m *Map[K, P]) Contains(key K) bool {
+	_, ok := m.impl.index[key]
+	return ok
+}
+
+// Delete removes the key from the Map.
+// If the key is not found, nothing happens.
+// The complexity is O(log n) where n = m.Len().
+func (m *Map[K, P]) Delete(key K) *Map[K, P] {
+	if i, ok := m.impl.index[key]; ok {
+		heap.Remove(m.impl, i)
+	}
+	return m
+}
+
+// Min returns the key with the lowest priority, along with its priority.
+// The complexity is O(1).
+func (m *Map[K, P]) Min() (K, P) {
+	e := m.impl.values[0]
+	return e.key, e.prio
+}
+
+// PopMin removes and returns the key with the lowest priority, along with its priority.
+// The complexity is O(log n) where n = m.Len().
+func (m *Map[K, P]) PopMin() (K, P) {
+	e := heap.Pop(m.impl).(entry[K, P])
+	return e.key, e.prio
+}
+
+type entry[K comparable, P any] struct {
+	key  K
+	prio P
+}
+
+// mapImpl is a heapImpl keeping track of the index of each key.
+type mapImpl[K comparable, P any] struct {
+	heapImpl[entry[K, P]]
+	index map[K]int
+}
+
+func (m *mapImpl[K, P]) Swap(i, j int) {
+	m.heapImpl.Swap(i, j)
+	m.index[m.values[i].key] = i
+	m.index[m.values[j].key] = j
+}
+
+func (m *mapImpl[K, P]) Push(x any) {
+	m.index[x.(entry[K, P]).key] = len(m.values)
+	m.heapImpl.Push(x)
+}
+
+func (m *mapImpl[K, P]) Pop() any {
+	x := m.heapImpl.Pop()
+	delete(m.index, x.(entry[K, P]).key)
+	return x
+}
@@ -0,0 +1,126 @@
+package heaps
+
+import "cmp"
+
+// Pairing is a [pairing heap], a heap-ordered multi-way tree.
+// Pushing an element or melding two heaps costs O(1), by simply linking the roots,
+// while the work of restructuring is deferred to Pop, which costs O(log n) amortized.
+//
+// A Pairing heap is not safe for concurrent use by multiple goroutines.
+//
+// [pairing heap]: https://en.wikipedia.org/wiki/Pairing_heap
+type Pairing[E any] struct {
+	root *pnode[E]
+	size int
+	less func(x, y E) bool
+}
+
+type pnode[E any] struct {
+	val     E
+	child   *pnode[E] // the first child
+	sibling *pnode[E] // the next sibling
+}
+
+// NewPairing creates a new pairing min-heap for ordered element types.
+// The initial values are optional.
+func NewPairing[E cmp.Ordered](values ...E) *Pairing[E] {
+	return NewPairingFunc(func(x, y E) bool { return x < y }, values...)
+}
+
+// NewPairingFunc creates a new pairing min-heap for any type.
+// The initial values are optional.
+func NewPairingFunc[E any](less func(x, y E) bool, values ...E) *Pairing[E] {
+	h := &Pairing[E]{less: less}
+	for _, v := range values {
+		h.Push(v)
+	}
+	return h
+}
+
+// Len returns number of elements in the heap.
+func (h *Pairing[E]) Len() int { return h.size }
+
+// Push pushes the element x onto the heap.
+// The complexity is O(1).
+func (h *Pairing[E]) Push(x E) *Pairing[E] {
+	h.root = h.meld(h.root, &pnode[E]{val: x})
+	h.size++
+	return h
+}
+
+// Pop removes and returns the first element from the heap.
+// The complexity is O(log n) amortized where n = h.Len().
+func (h *Pairing[E]) Pop() E {
+	root := h.root
+	h.root = h.pair(root.child)
+	h.size--
+	return root.val
+}
+
+// Top returns the first element from the heap.
+// The complexity is O(1).
+func (h *Pairing[E]) Top() E {
+	return h.root.val
+}
+
+// Meld moves all elements in h2 to h, and leaves h2 empty.
+// Both heaps are expected to be ordered by the same less function.
+// The complexity is O(1).
+func (h *Pairing[E]) Meld(h2 *Pairing[E]) *Pairing[E] {
+	if h2 == h {
+		return h
+	}
+
+	h.root = h.meld(h.root, h2.root)
+	h.size += h2.size
+	h2.root, h2.size = nil, 0
+	return h
+}
+
+// meld links two trees by making the greater root the first child of the other one.
+func (h *Pairing[E]) meld(a, b *pnode[E]) *pnode[E] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if h.less(b.val, a.val) {
+		a, b = b, a
+	}
+	b.sibling = a.child
+	a.child = b
+	return a
+}
+
+// pair melds the sibling trees into one in two passes:
+// melding the trees in pairs from left to right, then melding the results from right to left.
+func (h *Pairing[E]) pair(first *pnode[E]) *pnode[E] {
+	// the melded pairs are stacked up by their sibling links
+	var pairs *pnode[E]
+	for first != nil {
+		second := first.sibling
+		if second == nil {
+			first.sibling = pairs
+			pairs = first
+			break
+		}
+
+		next := second.sibling
+		first.sibling, second.sibling = nil, nil
+		pair := h.meld(first, second)
+		pair.sibling = pairs
+		pairs = pair
+		first = next
+	}
+
+	var root *pnode[E]
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = h.meld(root, pairs)
+		pairs = next
+	}
+	return root
+}
@@ -0,0 +1,85 @@
+// Package modeltest provides helpers for model-based testing of the containers.
+//
+// A container is driven by a sequence of random operations, along with a trivial reference implementation
+// (the model), e.g., a sorted slice for a skip list. The operations and their arguments are decoded from
+// the input of a native fuzz test, so any divergence found by the fuzzer is reproducible and minimized.
+package modeltest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Op is an operation applied to both the container and the model.
+type Op struct {
+	Name string
+
+	// Run applies the operation with arg, and returns an error if the container diverges from the model.
+	// The arg is a non-negative number decoded from the fuzz input.
+	Run func(arg int) error
+}
+
+// Drive decodes data into a sequence of operations and runs them in order.
+// Every three bytes are decoded as an operation chosen from ops, and its argument.
+// After each operation, check is called to compare the whole container with the model.
+//
+// The test fails at the first error, with the operations run so far to reproduce it.
+func Drive(t testing.TB, data []byte, ops []Op, check func() error) {
+	t.Helper()
+
+	var trace []string
+	for ; len(data) >= 3; data = data[3:] {
+		op := ops[int(data[0])%len(ops)]
+		arg := int(data[1])<<8 | int(data[2])
+		trace = append(trace, fmt.Sprintf("%s(%d)", op.Name, arg))
+
+		err := op.Run(arg)
+		if err == nil && check != nil {
+			err = check()
+		}
+		if err != nil {
+			t.Fatalf("%v\noperations:\n\t%s", err, strings.Join(trace, "\n\t"))
+		}
+	}
+}
+
+// Seeds returns some inputs to drive n operations, to be added into the seed corpus of a fuzz test.
+// The inputs are deterministic, so the seed corpus is the same for each run.
+func Seeds(n int) [][]byte {
+	seeds := make([][]byte, 0, 4)
+	for seed := uint32(1); seed <= 4; seed++ {
+		data := make([]byte, 3*n)
+		x := seed
+		for i := range data {
+			// xorshift
+			x ^= x << 13
+			x ^= x >> 17
+			x ^= x << 5
+			data[i] = byte(x)
+		}
+		seeds = append(seeds, data)
+	}
+	return seeds
+}
+
+// Equal returns an error if got and want are not equal.
+func Equal[E comparable](what string, got, want E) error {
+	if got != want {
+		return fmt.Errorf("%s: got %v, want %v", what, got, want)
+	}
+	return nil
+}
+
+// EqualSlices returns an error if got and want are not equal element by element.
+func EqualSlices[E comparable](what string, got, want []E) error {
+	if len(got) != len(want) {
+		return fmt.Errorf("%s: got %d elements %v, want %d elements %v", what, len(got), got, len(want), want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return fmt.Errorf("%s: element %d: got %v, want %v", what, i, got[i], want[i])
+		}
+	}
+	return nil
+}
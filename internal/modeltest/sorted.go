@@ -0,0 +1,55 @@
+package modeltest
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Sorted is a trivial sorted sequence backed by a slice, as the model of ordered containers.
+// All the operations cost O(n).
+type Sorted[E cmp.Ordered] struct {
+	Values []E
+
+	// Duplicates allows equal elements, which are kept in their insertion order.
+	Duplicates bool
+}
+
+// Set inserts v after all the elements not greater than it,
+// or overwrites the equal one if duplicates are not allowed.
+func (s *Sorted[E]) Set(v E) {
+	i, found := slices.BinarySearch(s.Values, v)
+	if found && !s.Duplicates {
+		s.Values[i] = v
+		return
+	}
+	for i < len(s.Values) && s.Values[i] == v {
+		i++
+	}
+	s.Values = slices.Insert(s.Values, i, v)
+}
+
+// Unset removes the first element equal to v.
+func (s *Sorted[E]) Unset(v E) {
+	if i, found := slices.BinarySearch(s.Values, v); found {
+		s.Values = slices.Delete(s.Values, i, i+1)
+	}
+}
+
+// Index returns index of the first element equal to v and true, or -1 and false.
+func (s *Sorted[E]) Index(v E) (int, bool) {
+	if i, found := slices.BinarySearch(s.Values, v); found {
+		return i, true
+	}
+	return -1, false
+}
+
+// Count returns number of elements equal to v.
+func (s *Sorted[E]) Count(v E) int {
+	n := 0
+	for _, e := range s.Values {
+		if e == v {
+			n++
+		}
+	}
+	return n
+}
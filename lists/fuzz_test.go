@@ -0,0 +1,119 @@
+package lists_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/houz42/abstract/internal/modeltest"
+	"github.com/houz42/abstract/lists"
+)
+
+func FuzzList(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		l := lists.New[int]()
+		var model []int
+
+		// at returns the i-th element in l, and the index of the one to operate on in model
+		at := func(arg int) (*lists.Element[int], int) {
+			i := arg % len(model)
+			e := l.Front()
+			for j := 0; j < i; j++ {
+				e = e.Next()
+			}
+			return e, i
+		}
+
+		ops := []modeltest.Op{
+			{Name: "PushFront", Run: func(arg int) error {
+				l.PushFront(arg)
+				model = slices.Insert(model, 0, arg)
+				return nil
+			}},
+			{Name: "PushBack", Run: func(arg int) error {
+				l.PushBack(arg)
+				model = append(model, arg)
+				return nil
+			}},
+			{Name: "InsertBefore", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				e, i := at(arg)
+				l.InsertBefore(arg, e)
+				model = slices.Insert(model, i, arg)
+				return nil
+			}},
+			{Name: "InsertAfter", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				e, i := at(arg)
+				l.InsertAfter(arg, e)
+				model = slices.Insert(model, i+1, arg)
+				return nil
+			}},
+			{Name: "MoveToFront", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				e, i := at(arg)
+				l.MoveToFront(e)
+				v := model[i]
+				model = slices.Insert(slices.Delete(model, i, i+1), 0, v)
+				return nil
+			}},
+			{Name: "MoveToBack", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				e, i := at(arg)
+				l.MoveToBack(e)
+				v := model[i]
+				model = append(slices.Delete(model, i, i+1), v)
+				return nil
+			}},
+			{Name: "Remove", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				e, i := at(arg)
+				got := l.Remove(e)
+				want := model[i]
+				model = slices.Delete(model, i, i+1)
+				return modeltest.Equal("Remove()", got, want)
+			}},
+			{Name: "PushBackList", Run: func(int) error {
+				if len(model) > 256 {
+					return nil
+				}
+				l.PushBackList(l)
+				model = append(model, model...)
+				return nil
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", l.Len(), len(model)); err != nil {
+				return err
+			}
+
+			var forward, backward []int
+			for e := l.Front(); e != nil; e = e.Next() {
+				forward = append(forward, e.Value())
+			}
+			for e := l.Back(); e != nil; e = e.Prev() {
+				backward = append(backward, e.Value())
+			}
+			slices.Reverse(backward)
+
+			if err := modeltest.EqualSlices("forward", forward, model); err != nil {
+				return err
+			}
+			return modeltest.EqualSlices("backward", backward, model)
+		})
+	})
+}
@@ -0,0 +1,100 @@
+package sets_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/houz42/abstract/internal/modeltest"
+	"github.com/houz42/abstract/sets"
+)
+
+func FuzzSet(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		const values = 64
+
+		// operations are done on s, while u is the other operand of binary operations
+		s, u := sets.New[int](), sets.New[int]()
+		sm, um := &modeltest.Sorted[int]{}, &modeltest.Sorted[int]{}
+
+		subset := func(a, b *modeltest.Sorted[int]) bool {
+			for _, v := range a.Values {
+				if _, ok := b.Index(v); !ok {
+					return false
+				}
+			}
+			return true
+		}
+
+		ops := []modeltest.Op{
+			{Name: "Set", Run: func(arg int) error {
+				s.Set(arg % values)
+				sm.Set(arg % values)
+				return nil
+			}},
+			{Name: "Unset", Run: func(arg int) error {
+				s.Unset(arg % values)
+				sm.Unset(arg % values)
+				return nil
+			}},
+			{Name: "SetOther", Run: func(arg int) error {
+				u.Set(arg % values)
+				um.Set(arg % values)
+				return nil
+			}},
+			{Name: "UnsetOther", Run: func(arg int) error {
+				u.Unset(arg % values)
+				um.Unset(arg % values)
+				return nil
+			}},
+			{Name: "Contains", Run: func(arg int) error {
+				_, want := sm.Index(arg % values)
+				return modeltest.Equal(fmt.Sprintf("Contains(%d)", arg%values), s.Contains(arg%values), want)
+			}},
+			{Name: "Clone", Run: func(int) error {
+				s = s.Clone()
+				return nil
+			}},
+			{Name: "Union", Run: func(int) error {
+				s = s.Union(u)
+				for _, v := range um.Values {
+					sm.Set(v)
+				}
+				return nil
+			}},
+			{Name: "Intersection", Run: func(int) error {
+				s = s.Intersection(u)
+				var values []int
+				for _, v := range sm.Values {
+					if _, ok := um.Index(v); ok {
+						values = append(values, v)
+					}
+				}
+				sm.Values = values
+				return nil
+			}},
+			{Name: "Subset", Run: func(int) error {
+				return modeltest.Equal("Subset()", s.Subset(u), subset(sm, um))
+			}},
+			{Name: "Superset", Run: func(int) error {
+				return modeltest.Equal("Superset()", s.Superset(u), subset(um, sm))
+			}},
+			{Name: "Equal", Run: func(int) error {
+				return modeltest.Equal("Equal()", s.Equal(u), slices.Equal(sm.Values, um.Values))
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			got := make([]int, 0, s.Len())
+			for v := range s {
+				got = append(got, v)
+			}
+			slices.Sort(got)
+			return modeltest.EqualSlices("elements", got, sm.Values)
+		})
+	})
+}
@@ -0,0 +1,216 @@
+package skiplists
+
+import "cmp"
+
+// Monoid describes how the elements are aggregated:
+// each element is measured into a value of type A,
+// and the measured values are combined with an associative operation,
+// whose identity element is Identity.
+//
+// For example, to sum up scores of players:
+//
+//	skiplists.Monoid[player, int]{
+//		Identity: 0,
+//		Measure:  func(p player) int { return p.score },
+//		Combine:  func(a, b int) int { return a + b },
+//	}
+type Monoid[V, A any] struct {
+	Identity A
+	Measure  func(V) A
+	Combine  func(a, b A) A
+}
+
+// AggregateList is a [SkipList] augmented with a [Monoid],
+// which aggregates the elements in any index range or value range in expected O(log n) time,
+// e.g., rolling sums over sorted event streams.
+//
+// Besides the width used for random accesses, each link in an AggregateList also keeps
+// the aggregation of the elements it skips over.
+// Combine is called expected O(log n) times for each update.
+//
+// An AggregateList is not safe for concurrent use by multiple goroutines.
+type AggregateList[V, A any] struct {
+	list   *SkipList[aggregated[V, A]]
+	monoid Monoid[V, A]
+}
+
+// aggregated is an element in an AggregateList,
+// along with aggregations of the links from its node on each level,
+// i.e., agg[level] is the aggregation of the elements in (this, next].
+// The aggregations of the head are kept in the value of the head node.
+type aggregated[V, A any] struct {
+	val V
+	agg []A
+}
+
+// NewAggregateList returns an [AggregateList] of any ordered elements aggregated by the monoid.
+func NewAggregateList[V cmp.Ordered, A any](monoid Monoid[V, A], options ...Option) *AggregateList[V, A] {
+	return NewAggregateListFunc(cmp.Compare[V], monoid, options...)
+}
+
+// NewAggregateListFunc returns an AggregateList of any type when a custom cmp function is provided.
+// The `cmp` function follows the same rules as the one passed to [NewFunc].
+// Elements in an AggregateList are always ranked, so option [SetRanks] has no effect.
+func NewAggregateListFunc[V, A any](cmp func(a, b V) int, monoid Monoid[V, A], options ...Option) *AggregateList[V, A] {
+	options = append(options[:len(options):len(options)], SetRanks(true))
+	return &AggregateList[V, A]{
+		list:   NewFunc(func(a, b aggregated[V, A]) int { return cmp(a.val, b.val) }, options...),
+		monoid: monoid,
+	}
+}
+
+// Len returns number of elements in the AggregateList.
+func (al *AggregateList[V, A]) Len() int { return al.list.Len() }
+
+// Options returns a copy of the options of the AggregateList.
+func (al *AggregateList[V, A]) Options() Options { return al.list.Options() }
+
+// Get returns an element and true if it is in the AggregateList,
+// otherwise zero value of type V and false.
+// If duplicates are allowed, the first one of the equal elements is returned.
+func (al *AggregateList[V, A]) Get(val V) (V, bool) {
+	v, ok := al.list.Get(aggregated[V, A]{val: val})
+	return v.val, ok
+}
+
+// At returns the i-th element in the AggregateList.
+// It panics if i is not valid, just like accessing slice element with an out-of-range index.
+func (al *AggregateList[V, A]) At(i int) V {
+	return al.list.At(i).val
+}
+
+// Set inserts an element into the AggregateList.
+// If the element is already in, the element will be overwritten with the input value,
+// unless duplicates are allowed, in which case the element is inserted after all the equal ones.
+func (al *AggregateList[V, A]) Set(val V) *AggregateList[V, A] {
+	key := aggregated[V, A]{val: val}
+
+	// overwrite in place to keep the aggregations of the node
+	nd, i := al.list.seek(key, true)
+	if !al.list.opt.Duplicates && nd != al.list.head && al.list.cmp(nd.val, key) == 0 {
+		nd.val.val = val
+		al.refresh(nd, i)
+		return al
+	}
+
+	al.list.Set(key)
+	nd, i = al.list.seek(key, true) // the last one of the equal elements
+	al.refresh(nd, i)
+	return al
+}
+
+// Unset removes an element from the AggregateList.
+// If the element is not found, nothing happens.
+// If duplicates are allowed, only the first one of the equal elements is removed.
+func (al *AggregateList[V, A]) Unset(val V) *AggregateList[V, A] {
+	key := aggregated[V, A]{val: val}
+	nd, pos := al.list.seek(key, false)
+	if nd.tower[0].next == nil || al.list.cmp(nd.tower[0].next.val, key) != 0 {
+		return al
+	}
+
+	al.remove(pos + 1)
+	return al
+}
+
+// RemoveAt removes the i-th element in the AggregateList.
+// It panics if i is not valid, just like accessing slice element with an out-of-range index.
+func (al *AggregateList[V, A]) RemoveAt(i int) *AggregateList[V, A] {
+	al.remove(i)
+	return al
+}
+
+// Rebalance rebuilds the AggregateList into a perfectly balanced one, see [SkipList.Rebalance].
+// The aggregations are recalculated, Combine is called O(n) times.
+func (al *AggregateList[V, A]) Rebalance() *AggregateList[V, A] {
+	al.list.Rebalance()
+
+	for level := 0; level < al.list.level; level++ {
+		for nd := al.list.head; nd != nil; nd = nd.tower[level].next {
+			al.aggregate(nd, level)
+		}
+	}
+	return al
+}
+
+// Aggregate returns the aggregation of the elements with indexes in [i, j).
+// It returns the identity of the monoid if the range is empty.
+// It panics if the range is not valid, just like slicing a slice with out-of-range indexes.
+func (al *AggregateList[V, A]) Aggregate(i, j int) A {
+	al.list.checkRange(i, j)
+
+	agg := al.monoid.Identity
+	nd, pos := al.list.before(i)
+
+	// climb up and down along the highest links not beyond the range
+	for pos < j-1 {
+		level := min(len(nd.tower), al.list.level) - 1
+		for nd.tower[level].next == nil || pos+nd.tower[level].width > j-1 {
+			level--
+		}
+		agg = al.monoid.Combine(agg, nd.val.agg[level])
+		pos += nd.tower[level].width
+		nd = nd.tower[level].next
+	}
+
+	return agg
+}
+
+// AggregateBetween returns the aggregation of the elements between lo and hi.
+// Whether lo and hi themselves are included is decided by b.
+func (al *AggregateList[V, A]) AggregateBetween(lo, hi V, b Bounds) A {
+	i, j := al.list.between(aggregated[V, A]{val: lo}, aggregated[V, A]{val: hi}, b)
+	if j <= i {
+		return al.monoid.Identity
+	}
+	return al.Aggregate(i, j)
+}
+
+// remove removes the i-th element, and refreshes the aggregations of its predecessors.
+func (al *AggregateList[V, A]) remove(i int) {
+	var updatesBuf [stackLevels]*node[aggregated[V, A]]
+	updates := al.list.predecessors(i, buffer(updatesBuf[:], al.list.level))
+
+	al.list.RemoveAt(i)
+
+	// the removed levels are not refreshed
+	for level := 0; level < al.list.level; level++ {
+		al.aggregate(updates[level], level)
+	}
+}
+
+// refresh recalculates aggregations of the links changed by setting the i-th element,
+// which are the ones from its node and its predecessors, level by level.
+func (al *AggregateList[V, A]) refresh(nd *node[aggregated[V, A]], i int) {
+	var updatesBuf [stackLevels]*node[aggregated[V, A]]
+	updates := al.list.predecessors(i, buffer(updatesBuf[:], al.list.level))
+
+	for level := 0; level < al.list.level; level++ {
+		if level < len(nd.tower) {
+			al.aggregate(nd, level)
+		}
+		al.aggregate(updates[level], level)
+	}
+}
+
+// aggregate recalculates aggregation of the link from nd on the level,
+// from the links on the lower level.
+func (al *AggregateList[V, A]) aggregate(nd *node[aggregated[V, A]], level int) {
+	if n := len(nd.tower); len(nd.val.agg) < n {
+		nd.val.agg = append(nd.val.agg, make([]A, n-len(nd.val.agg))...)
+	}
+
+	end := nd.tower[level].next
+	switch {
+	case end == nil:
+		nd.val.agg[level] = al.monoid.Identity
+	case level == 0:
+		nd.val.agg[0] = al.monoid.Measure(end.val.val)
+	default:
+		agg := al.monoid.Identity
+		for x := nd; x != end; x = x.tower[level-1].next {
+			agg = al.monoid.Combine(agg, x.val.agg[level-1])
+		}
+		nd.val.agg[level] = agg
+	}
+}
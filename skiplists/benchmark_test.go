@@ -17,8 +17,14 @@ func BenchmarkSkipList(b *testing.B) {
 	}
 }
 
+// newEmptyList returns a list with its own rand source,
+// so that the layout is reproducible and there is no contention on the global source.
+func newEmptyList() *skiplists.SkipList[int] {
+	return skiplists.New[int](skiplists.SetRandSource(rand.NewSource(1)))
+}
+
 func newList(size int) *skiplists.SkipList[int] {
-	list := skiplists.New[int]()
+	list := newEmptyList()
 	for i := 0; i < size; i++ {
 		list.Set(i)
 	}
@@ -38,7 +44,19 @@ func basicReadWrite(b *testing.B, size int) {
 	b.Run("set", func(b *testing.B) {
 		b.Run("forward", func(b *testing.B) {
 			for x := 0; x < b.N; x++ {
-				list := skiplists.New[int]()
+				list := newEmptyList()
+				for i := 0; i < size; i++ {
+					list.Set(i)
+				}
+				if list.Len() != size {
+					b.Fatal()
+				}
+			}
+		})
+
+		b.Run("forward without ranks", func(b *testing.B) {
+			for x := 0; x < b.N; x++ {
+				list := skiplists.New[int](skiplists.SetRanks(false), skiplists.SetRandSource(rand.NewSource(1)))
 				for i := 0; i < size; i++ {
 					list.Set(i)
 				}
@@ -48,9 +66,24 @@ func basicReadWrite(b *testing.B, size int) {
 			}
 		})
 
+		b.Run("from sorted", func(b *testing.B) {
+			values := make([]int, size)
+			for i := range values {
+				values[i] = i
+			}
+			b.ResetTimer()
+
+			for x := 0; x < b.N; x++ {
+				list, err := skiplists.FromSorted(values, skiplists.SetRandSource(rand.NewSource(1)))
+				if err != nil || list.Len() != size {
+					b.Fatal()
+				}
+			}
+		})
+
 		b.Run("backward", func(b *testing.B) {
 			for x := 0; x < b.N; x++ {
-				list := skiplists.New[int]()
+				list := newEmptyList()
 				for i := 0; i < size; i++ {
 					list.Set(size - i)
 				}
@@ -62,7 +95,7 @@ func basicReadWrite(b *testing.B, size int) {
 
 		b.Run("random", func(b *testing.B) {
 			for x := 0; x < b.N; x++ {
-				list := skiplists.New[int]()
+				list := newEmptyList()
 				for _, i := range random {
 					list.Set(i)
 				}
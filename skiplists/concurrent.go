@@ -0,0 +1,295 @@
+package skiplists
+
+import (
+	"cmp"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Concurrent is a skip list which is safe for concurrent use by multiple goroutines.
+//
+// It is implemented as a [lazy skip list]: Get never blocks,
+// while Set and Unset only lock the nodes just before the position being changed,
+// so operations on different parts of the list run in parallel.
+//
+// Unlike [SkipList], Concurrent does not track the indexes of its elements,
+// so there are no random access methods. Use [Concurrent.Snapshot] to get a consistent copy
+// of all the elements if they are needed.
+//
+// [lazy skip list]: https://people.csail.mit.edu/shanir/publications/LazySkipList.pdf
+type Concurrent[V any] struct {
+	// head is replaced by a taller one when a higher element is inserted,
+	// so its height is the high-water level of the elements, where searches start from.
+	head atomic.Pointer[cnode[V]]
+	size atomic.Int64
+	cmp  func(a, b V) int
+	opt  Options
+
+	// writers share the gate, and snapshots and growing of the head take it exclusively to stop the world.
+	gate sync.RWMutex
+
+	// guards the rand source provided by option
+	randMu sync.Mutex
+}
+
+type cnode[V any] struct {
+	key  V // for comparison only, it never changes once the node is created
+	val  atomic.Pointer[V]
+	next []atomic.Pointer[cnode[V]]
+
+	mu          sync.Mutex
+	marked      atomic.Bool // logically removed
+	fullyLinked atomic.Bool
+}
+
+// maximum height of the towers in a Concurrent skip list
+const concurrentMaxLevel = 32
+
+// NewConcurrent returns a [Concurrent] skip list of any ordered elements.
+func NewConcurrent[V cmp.Ordered](options ...Option) *Concurrent[V] {
+	return NewConcurrentFunc[V](cmp.Compare, options...)
+}
+
+// NewConcurrentFunc returns a Concurrent skip list of any type when a custom cmp function is provided.
+// The `cmp` function follows the same rules as the one passed to [NewFunc].
+// Elements in a Concurrent skip list are always unique, so option [SetDuplicates] has no effect.
+func NewConcurrentFunc[V any](cmp func(a, b V) int, options ...Option) *Concurrent[V] {
+	c := &Concurrent[V]{
+		cmp: cmp,
+		opt: defaultOptions,
+	}
+	c.head.Store(newHead[V](1))
+
+	for _, op := range options {
+		op(&c.opt)
+	}
+	c.opt.Duplicates = false
+
+	return c
+}
+
+// Len returns number of elements in the skip list.
+// The result may be outdated as soon as it is returned if there are concurrent writers.
+func (c *Concurrent[V]) Len() int { return int(c.size.Load()) }
+
+// Get returns an element and true if it is in the skip list,
+// otherwise zero value of type V and false.
+func (c *Concurrent[V]) Get(val V) (V, bool) {
+	var preds, succs [concurrentMaxLevel]*cnode[V]
+	found := c.find(val, &preds, &succs)
+	if found < 0 || !succs[found].fullyLinked.Load() || succs[found].marked.Load() {
+		var v V
+		return v, false
+	}
+	return *succs[found].val.Load(), true
+}
+
+// Set inserts an element into the skip list.
+// If the element is already in, the element will be overwritten with the input value.
+func (c *Concurrent[V]) Set(val V) *Concurrent[V] {
+	top := c.randomLevel()
+	c.grow(top)
+
+	c.gate.RLock()
+	defer c.gate.RUnlock()
+
+	var preds, succs [concurrentMaxLevel]*cnode[V]
+
+	for {
+		if found := c.find(val, &preds, &succs); found >= 0 {
+			nd := succs[found]
+			if nd.marked.Load() {
+				// being removed, try again after it is gone
+				runtime.Gosched()
+				continue
+			}
+			for !nd.fullyLinked.Load() {
+				runtime.Gosched()
+			}
+			nd.val.Store(&val)
+			return c
+		}
+
+		locked, valid := c.lockPreds(&preds, top, func(level int, pred *cnode[V]) bool {
+			succ := succs[level]
+			return !pred.marked.Load() && (succ == nil || !succ.marked.Load()) &&
+				pred.next[level].Load() == succ
+		})
+		if !valid {
+			unlockAll(locked)
+			continue
+		}
+
+		nd := &cnode[V]{key: val, next: make([]atomic.Pointer[cnode[V]], top)}
+		nd.val.Store(&val)
+		for level := 0; level < top; level++ {
+			nd.next[level].Store(succs[level])
+		}
+		for level := 0; level < top; level++ {
+			preds[level].next[level].Store(nd)
+		}
+		nd.fullyLinked.Store(true)
+
+		unlockAll(locked)
+		c.size.Add(1)
+		return c
+	}
+}
+
+// Unset removes an element from the skip list.
+// If the element is not found, nothing happens.
+func (c *Concurrent[V]) Unset(val V) *Concurrent[V] {
+	c.gate.RLock()
+	defer c.gate.RUnlock()
+
+	var victim *cnode[V]
+	var preds, succs [concurrentMaxLevel]*cnode[V]
+
+	for {
+		found := c.find(val, &preds, &succs)
+
+		if victim == nil {
+			if found < 0 {
+				return c
+			}
+
+			nd := succs[found]
+			if !nd.fullyLinked.Load() || len(nd.next)-1 != found || nd.marked.Load() {
+				// not fully inserted yet, or being removed by others
+				return c
+			}
+
+			nd.mu.Lock()
+			if nd.marked.Load() {
+				nd.mu.Unlock()
+				return c
+			}
+			nd.marked.Store(true)
+			victim = nd
+		}
+
+		top := len(victim.next)
+		locked, valid := c.lockPreds(&preds, top, func(level int, pred *cnode[V]) bool {
+			return !pred.marked.Load() && pred.next[level].Load() == victim
+		})
+		if !valid {
+			unlockAll(locked)
+			continue
+		}
+
+		for level := top - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.next[level].Load())
+		}
+
+		victim.mu.Unlock()
+		unlockAll(locked)
+		c.size.Add(-1)
+		return c
+	}
+}
+
+// Snapshot returns a SkipList contains all the elements in the skip list at some point of time.
+// Writers are blocked while the snapshot is being taken, readers are not affected.
+// The returned SkipList shares no memory with c, and can be used as a normal SkipList.
+func (c *Concurrent[V]) Snapshot() *SkipList[V] {
+	c.gate.Lock()
+	defer c.gate.Unlock()
+
+	sl := NewFunc(c.cmp)
+	sl.opt = c.opt
+	sl.opt.rand = nil // guarded by c.randMu, do not share it
+
+	values := make([]V, 0, c.Len())
+	for nd := c.head.Load().next[0].Load(); nd != nil; nd = nd.next[0].Load() {
+		values = append(values, *nd.val.Load())
+	}
+	sl.fill(values)
+
+	return sl
+}
+
+// newHead returns a head node with the height.
+func newHead[V any](height int) *cnode[V] {
+	head := &cnode[V]{next: make([]atomic.Pointer[cnode[V]], height)}
+	head.fullyLinked.Store(true)
+	return head
+}
+
+// grow replaces the head with one as high as top, if the current one is lower than it.
+// Writers are stopped while the links are copied to the new head.
+// Readers still searching from the old head are not affected, as its links are not changed any more.
+func (c *Concurrent[V]) grow(top int) {
+	if len(c.head.Load().next) >= top {
+		return
+	}
+
+	c.gate.Lock()
+	defer c.gate.Unlock()
+
+	old := c.head.Load()
+	if len(old.next) >= top {
+		return
+	}
+	head := newHead[V](top)
+	for level := range old.next {
+		head.next[level].Store(old.next[level].Load())
+	}
+	c.head.Store(head)
+}
+
+// find fills the predecessors and successors of val on each level below the height of the head,
+// and returns the highest level on which val is found, or -1 if it is not found.
+func (c *Concurrent[V]) find(val V, preds, succs *[concurrentMaxLevel]*cnode[V]) int {
+	found := -1
+	pred := c.head.Load()
+	for level := len(pred.next) - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && c.cmp(curr.key, val) < 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if found < 0 && curr != nil && c.cmp(curr.key, val) == 0 {
+			found = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+	return found
+}
+
+// lockPreds locks the distinct predecessors from the bottom level up to top,
+// and reports whether all of them are validated.
+// The locked nodes are returned even if the validation fails, so that they can be unlocked.
+func (c *Concurrent[V]) lockPreds(
+	preds *[concurrentMaxLevel]*cnode[V], top int, validate func(level int, pred *cnode[V]) bool,
+) ([]*cnode[V], bool) {
+	locked := make([]*cnode[V], 0, top)
+	var prev *cnode[V]
+	for level := 0; level < top; level++ {
+		pred := preds[level]
+		if pred != prev {
+			pred.mu.Lock()
+			locked = append(locked, pred)
+			prev = pred
+		}
+		if !validate(level, pred) {
+			return locked, false
+		}
+	}
+	return locked, true
+}
+
+func unlockAll[V any](nodes []*cnode[V]) {
+	for _, nd := range nodes {
+		nd.mu.Unlock()
+	}
+}
+
+func (c *Concurrent[V]) randomLevel() int {
+	if c.opt.rand != nil {
+		c.randMu.Lock()
+		defer c.randMu.Unlock()
+	}
+	return min(c.opt.randomLevel(c.Len()), concurrentMaxLevel)
+}
@@ -0,0 +1,130 @@
+package skiplists_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/houz42/abstract/skiplists"
+)
+
+func TestConcurrent(t *testing.T) {
+	const (
+		workers = 8
+		size    = 2000
+	)
+
+	list := skiplists.NewConcurrent[int]()
+
+	// every worker owns the values v with v%workers == w,
+	// and races with others on the shared values v >= size.
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for v := w; v < size; v += workers {
+				list.Set(v)
+			}
+			for v := w; v < size; v += 2 * workers {
+				list.Unset(v)
+			}
+			for i := 0; i < size; i++ {
+				v := size + rand.Intn(100)
+				switch rand.Intn(3) {
+				case 0:
+					list.Set(v)
+				case 1:
+					list.Unset(v)
+				case 2:
+					if got, ok := list.Get(v); ok && got != v {
+						t.Errorf("got %d, want %d", got, v)
+					}
+				}
+			}
+			for v := size; v < size+100; v++ {
+				list.Unset(v)
+			}
+		}(w)
+	}
+
+	// snapshots are taken while writers are running
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			snapshot := list.Snapshot()
+			for j := 1; j < snapshot.Len(); j++ {
+				if snapshot.At(j-1) >= snapshot.At(j) {
+					t.Errorf("snapshot is not ordered at %d", j)
+				}
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if list.Len() != size/2 {
+		t.Fatalf("expecting %d elements, got %d", size/2, list.Len())
+	}
+	for v := 0; v < size; v++ {
+		_, ok := list.Get(v)
+		if want := v%(2*workers) >= workers; ok != want {
+			t.Fatalf("expecting %d in list to be %v, got %v", v, want, ok)
+		}
+	}
+
+	snapshot := list.Snapshot()
+	if snapshot.Len() != size/2 {
+		t.Fatalf("expecting %d elements in snapshot, got %d", size/2, snapshot.Len())
+	}
+}
+
+func BenchmarkConcurrent(b *testing.B) {
+	for size := 1000; size < 1_000_000; size *= 10 {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.Run("concurrent", func(b *testing.B) {
+				list := skiplists.NewConcurrent[int]()
+				for i := 0; i < size; i++ {
+					list.Set(i)
+				}
+				b.ResetTimer()
+
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						v := rand.Intn(size)
+						if v%10 == 0 {
+							list.Unset(v)
+							list.Set(v)
+						} else {
+							list.Get(v)
+						}
+					}
+				})
+			})
+
+			b.Run("mutex", func(b *testing.B) {
+				var mu sync.RWMutex
+				list := newList(size)
+				b.ResetTimer()
+
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						v := rand.Intn(size)
+						if v%10 == 0 {
+							mu.Lock()
+							list.Unset(v)
+							list.Set(v)
+							mu.Unlock()
+						} else {
+							mu.RLock()
+							list.Get(v)
+							mu.RUnlock()
+						}
+					}
+				})
+			})
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package skiplists
+
+import "fmt"
+
+// Cursor is a stateful position in a [SkipList], which can be moved in both directions.
+// Moving a cursor to the adjacent element costs O(1), and it could be used to resume scanning from
+// an arbitrary position without searching from the beginning every time.
+//
+// Besides the elements, a cursor could also be placed before the first element, or after the last one,
+// where it is not valid to read the value.
+//
+// Modifying the SkipList through other means than [Cursor.Delete] invalidates the cursor,
+// it should be placed again by [Cursor.Seek] or [Cursor.SeekIndex] before further use.
+type Cursor[V any] struct {
+	sl   *SkipList[V]
+	node *node[V] // head of the list when before the first element, nil when after the last one
+	pos  int
+}
+
+// Cursor returns a new cursor placed before the first element of the SkipList.
+func (sl *SkipList[V]) Cursor() *Cursor[V] {
+	return &Cursor[V]{sl: sl, node: sl.head, pos: -1}
+}
+
+// Valid reports whether the cursor is placed at an element.
+func (c *Cursor[V]) Valid() bool { return c.node != nil && c.node != c.sl.head }
+
+// Index returns index of the element the cursor is placed at.
+// It is -1 if the cursor is before the first element, or length of the SkipList if after the last one.
+func (c *Cursor[V]) Index() int { return c.pos }
+
+// Value returns the element the cursor is placed at.
+// It panics if the cursor is not valid.
+func (c *Cursor[V]) Value() V {
+	if !c.Valid() {
+		panic(fmt.Errorf("runtime error: cursor at index %d with skip list length %d", c.pos, c.sl.size))
+	}
+	return c.node.val
+}
+
+// Seek places the cursor at the first element not less than val,
+// or after the last element if there is no such element.
+// It reports whether the cursor is valid.
+func (c *Cursor[V]) Seek(val V) bool {
+	nd, pos := c.sl.seek(val, false)
+	c.node, c.pos = nd.tower[0].next, c.sl.rank(nd, pos)+1
+	return c.Valid()
+}
+
+// SeekIndex places the cursor at the i-th element.
+// The cursor is placed before the first element if i < 0,
+// or after the last element if i >= length of the SkipList.
+// It reports whether the cursor is valid.
+func (c *Cursor[V]) SeekIndex(i int) bool {
+	switch {
+	case i < 0:
+		c.node, c.pos = c.sl.head, -1
+	case i >= c.sl.size:
+		c.node, c.pos = nil, c.sl.size
+	default:
+		nd, pos := c.sl.before(i)
+		c.node, c.pos = nd.tower[0].next, pos+1
+	}
+	return c.Valid()
+}
+
+// Next moves the cursor to the next element, and reports whether the cursor is valid.
+// Calling Next on a new cursor moves it to the first element.
+func (c *Cursor[V]) Next() bool {
+	if c.node == nil {
+		return false
+	}
+	c.node = c.node.tower[0].next
+	c.pos++
+	return c.Valid()
+}
+
+// Prev moves the cursor to the previous element, and reports whether the cursor is valid.
+// Calling Prev on a cursor after the last element moves it to the last element.
+func (c *Cursor[V]) Prev() bool {
+	if c.node == c.sl.head {
+		return false
+	}
+	if c.node == nil {
+		c.node = c.sl.last()
+	} else {
+		c.node = c.node.prev
+	}
+	c.pos--
+	return c.Valid()
+}
+
+// Delete removes the element the cursor is placed at from the SkipList,
+// moves the cursor to the next element, and reports whether the cursor is valid.
+// It panics if the cursor is not valid.
+func (c *Cursor[V]) Delete() bool {
+	if !c.Valid() {
+		panic(fmt.Errorf("runtime error: cursor at index %d with skip list length %d", c.pos, c.sl.size))
+	}
+
+	// links of the removed node are kept untouched
+	next := c.node.tower[0].next
+	c.sl.RemoveAt(c.pos)
+	c.node = next
+	return c.Valid()
+}
@@ -0,0 +1,81 @@
+package skiplists
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+)
+
+// ErrNoCmp is returned when decoding into a SkipList without the cmp function.
+var ErrNoCmp = errors.New("skiplists: cannot decode into a SkipList without cmp function, create it with New or NewFunc")
+
+// MarshalJSON implements [json.Marshaler], the SkipList is encoded as a JSON array.
+// A zero value SkipList, which is not created by [New] or [NewFunc], is encoded as an empty array.
+func (sl *SkipList[V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sl.values())
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+//
+// The elements are expected to be sorted by the cmp function of the receiver,
+// so the SkipList is rebuilt in O(n) time like [FromSortedFunc], and [ErrUnsorted] is returned if they are not.
+// Decoding replaces all the elements in the receiver, while its cmp function and options are kept.
+// Thus the receiver must be created by [New] or [NewFunc] beforehand to provide the cmp function,
+// otherwise [ErrNoCmp] is returned.
+func (sl *SkipList[V]) UnmarshalJSON(data []byte) error {
+	var values []V
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	return sl.decode(values)
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler], the elements are encoded by [encoding/gob].
+// A zero value SkipList is encoded as if it is empty.
+func (sl *SkipList[V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(sl.values()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler],
+// the elements are decoded in the same way as [SkipList.UnmarshalJSON].
+func (sl *SkipList[V]) UnmarshalBinary(data []byte) error {
+	var values []V
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+	return sl.decode(values)
+}
+
+// GobEncode implements [gob.GobEncoder], it is the same as [SkipList.MarshalBinary].
+func (sl *SkipList[V]) GobEncode() ([]byte, error) { return sl.MarshalBinary() }
+
+// GobDecode implements [gob.GobDecoder], it is the same as [SkipList.UnmarshalBinary].
+func (sl *SkipList[V]) GobDecode(data []byte) error { return sl.UnmarshalBinary(data) }
+
+// values returns all the elements to be encoded, none for a zero value SkipList.
+func (sl *SkipList[V]) values() []V {
+	if sl.head == nil {
+		return []V{}
+	}
+	return sl.Slice(0, sl.size)
+}
+
+func (sl *SkipList[V]) decode(values []V) error {
+	if sl.cmp == nil {
+		return ErrNoCmp
+	}
+
+	decoded := NewFunc(sl.cmp)
+	decoded.opt = sl.opt
+	if err := decoded.build(values); err != nil {
+		return err
+	}
+
+	*sl = *decoded
+	return nil
+}
@@ -28,3 +28,88 @@ func ExampleSkipList_All() {
 	// 3 gopher
 	// 4 is
 }
+
+func ExampleMap_All() {
+	m := skiplists.NewMap[int, string]()
+
+	m.Put(3, "three")
+	m.Put(1, "one")
+	m.Put(2, "two")
+
+	for k, v := range m.All() {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// 1 one
+	// 2 two
+	// 3 three
+}
+
+func ExampleSkipList_Between() {
+	list := skiplists.New[int]()
+
+	for i := 10; i <= 90; i += 10 {
+		list.Set(i)
+	}
+
+	for i, v := range list.Between(25, 60, skiplists.RightOpen) {
+		fmt.Println(i, v)
+	}
+
+	// Output:
+	// 2 30
+	// 3 40
+	// 4 50
+}
+
+func ExampleSkipList_Backward() {
+	list := skiplists.New[string]()
+
+	list.Set("Hello")
+	list.Set("gopher")
+	list.Set("Go")
+
+	for i, v := range list.Backward() {
+		fmt.Println(i, v)
+	}
+
+	// Output:
+	// 2 gopher
+	// 1 Hello
+	// 0 Go
+}
+
+func ExampleIntervalList_All() {
+	ranges := skiplists.NewIntervalList[int, string]()
+
+	ranges.Set(10, 20, "b")
+	ranges.Set(1, 5, "a")
+	ranges.Set(30, 40, "c")
+
+	for iv := range ranges.All() {
+		fmt.Println(iv.Lo, iv.Hi, iv.Value)
+	}
+
+	// Output:
+	// 1 5 a
+	// 10 20 b
+	// 30 40 c
+}
+
+func ExampleSortedSet_All() {
+	scores := skiplists.NewSortedSet[string, float64]()
+
+	scores.Add("b", 1.5)
+	scores.Add("a", 1.5)
+	scores.Add("c", 0.5)
+
+	for member, score := range scores.All() {
+		fmt.Println(member, score)
+	}
+
+	// Output:
+	// c 0.5
+	// a 1.5
+	// b 1.5
+}
@@ -1,9 +1,16 @@
 package skiplists_test
 
 import (
+	"bytes"
 	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/houz42/abstract/skiplists"
 )
@@ -82,6 +89,28 @@ func ExampleNewFunc() {
 	// is
 }
 
+func ExampleSkipList_Set() {
+	type score struct {
+		player string
+		points int
+	}
+
+	// players are compared by names only
+	list := skiplists.NewFunc[score](func(a, b score) int {
+		return cmp.Compare(a.player, b.player)
+	})
+
+	list.Set(score{player: "alice", points: 10})
+	list.Set(score{player: "alice", points: 20})
+
+	fmt.Println(list.Len())
+	fmt.Println(list.At(0).points)
+
+	// Output:
+	// 1
+	// 20
+}
+
 func ExampleSkipList_At() {
 	list := skiplists.New[int]()
 
@@ -104,3 +133,537 @@ func ExampleSkipList_At() {
 	// 4
 	// 5
 }
+
+func ExampleMap() {
+	ages := skiplists.NewMap[string, int]()
+
+	ages.Put("carol", 31)
+	ages.Put("alice", 27)
+	ages.Put("bob", 45)
+	ages.Put("alice", 28)
+
+	ages.Delete("bob")
+
+	fmt.Println(ages.Get("alice"))
+	fmt.Println(ages.Has("bob"))
+
+	for i := 0; i < ages.Len(); i++ {
+		fmt.Println(ages.At(i))
+	}
+
+	// Output:
+	// 28 true
+	// false
+	// alice 28
+	// carol 31
+}
+
+func ExampleSkipList_Floor() {
+	list := skiplists.New[int]()
+
+	list.Set(10)
+	list.Set(20)
+	list.Set(30)
+
+	fmt.Println(list.Floor(25))
+	fmt.Println(list.Ceiling(25))
+	fmt.Println(list.Lower(20))
+	fmt.Println(list.Higher(20))
+	fmt.Println(list.Floor(5))
+
+	// Output:
+	// 20 true
+	// 30 true
+	// 10 true
+	// 30 true
+	// 0 false
+}
+
+func ExampleSkipList_Range() {
+	list := skiplists.New[int]()
+
+	for i := 1; i <= 9; i++ {
+		list.Set(i)
+	}
+
+	fmt.Println(list.Range(3, 6, skiplists.Closed))
+	fmt.Println(list.Range(3, 6, skiplists.Open))
+	fmt.Println(list.Range(3, 6, skiplists.LeftOpen))
+	fmt.Println(list.Range(3, 6, skiplists.RightOpen))
+
+	// Output:
+	// [3 4 5 6]
+	// [4 5]
+	// [4 5 6]
+	// [3 4 5]
+}
+
+func ExampleSkipList_IndexOf() {
+	scores := skiplists.New[int]()
+
+	for _, s := range []int{72, 95, 88, 60, 79, 91} {
+		scores.Set(s)
+	}
+
+	fmt.Println(scores.IndexOf(88))
+	fmt.Println(scores.IndexOf(89))
+	fmt.Println(scores.CountLess(80))
+	fmt.Println(scores.CountBetween(70, 90, skiplists.Closed))
+
+	// Output:
+	// 3 true
+	// -1 false
+	// 3
+	// 3
+}
+
+func ExampleSkipList_RemoveRange() {
+	list := skiplists.New[int]()
+
+	for i := 1; i <= 9; i++ {
+		list.Set(i)
+	}
+
+	fmt.Println(list.Slice(2, 5))
+
+	// keep the top 5 elements only
+	list.RemoveRange(0, list.Len()-5)
+	fmt.Println(list.Slice(0, list.Len()))
+
+	list.RemoveBetween(6, 8, skiplists.Closed)
+	fmt.Println(list.Slice(0, list.Len()))
+
+	// Output:
+	// [3 4 5]
+	// [5 6 7 8 9]
+	// [5 9]
+}
+
+func ExampleSetDuplicates() {
+	type event struct {
+		timestamp int
+		name      string
+	}
+
+	events := skiplists.NewFunc[event](func(a, b event) int {
+		return cmp.Compare(a.timestamp, b.timestamp)
+	}, skiplists.SetDuplicates(true))
+
+	events.Set(event{timestamp: 2, name: "deploy"})
+	events.Set(event{timestamp: 1, name: "build"})
+	events.Set(event{timestamp: 2, name: "notify"})
+	events.Set(event{timestamp: 1, name: "test"})
+	events.Set(event{timestamp: 2, name: "cleanup"})
+
+	fmt.Println(events.Count(event{timestamp: 2}))
+	fmt.Println(events.IndexOf(event{timestamp: 2}))
+
+	events.UnsetOne(event{timestamp: 1})
+	for i := 0; i < events.Len(); i++ {
+		fmt.Println(events.At(i))
+	}
+
+	events.UnsetAll(event{timestamp: 2})
+	fmt.Println(events.Len())
+
+	// Output:
+	// 3
+	// 2 true
+	// {1 test}
+	// {2 deploy}
+	// {2 notify}
+	// {2 cleanup}
+	// 1
+}
+
+func ExampleConcurrent() {
+	list := skiplists.NewConcurrent[int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for v := i; v < 20; v += 4 {
+				list.Set(v)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	list.Unset(7)
+
+	fmt.Println(list.Len())
+	fmt.Println(list.Get(7))
+	fmt.Println(list.Snapshot().Slice(0, 8))
+
+	// Output:
+	// 19
+	// 0 false
+	// [0 1 2 3 4 5 6 8]
+}
+
+func ExampleSetRandSource() {
+	// lists built from the same seed and the same operations have the same layout
+	list := skiplists.New[int](skiplists.SetRandSource(rand.NewSource(42)))
+
+	for i := 0; i < 5; i++ {
+		list.Set(i)
+	}
+
+	fmt.Println(list.Slice(0, list.Len()))
+
+	// Output:
+	// [0 1 2 3 4]
+}
+
+func ExampleFromSorted() {
+	list, err := skiplists.FromSorted([]int{1, 3, 5, 7, 9})
+	fmt.Println(list.Slice(0, list.Len()), err)
+
+	_, err = skiplists.FromSorted([]int{1, 5, 3})
+	fmt.Println(errors.Is(err, skiplists.ErrUnsorted))
+
+	_, err = skiplists.FromSorted([]int{1, 1, 3})
+	fmt.Println(err)
+
+	list, err = skiplists.FromSorted([]int{1, 1, 3}, skiplists.SetDuplicates(true))
+	fmt.Println(list.Count(1), err)
+
+	// Output:
+	// [1 3 5 7 9] <nil>
+	// true
+	// skiplists: values are not sorted: values[1] equals to its predecessor
+	// 2 <nil>
+}
+
+func ExampleSkipList_Reverse() {
+	list := skiplists.New[int]()
+
+	list.Set(1)
+	list.Set(3)
+	list.Set(2)
+
+	reversed := list.Reverse()
+	reversed.Set(4)
+	reversed.Unset(1)
+
+	fmt.Println(list.Slice(0, list.Len()))
+	fmt.Println(reversed.Slice(0, reversed.Len()))
+	fmt.Println(reversed.At(0))
+	fmt.Println(reversed.Floor(3))
+
+	// Output:
+	// [1 2 3]
+	// [4 3 2]
+	// 4
+	// 3 true
+}
+
+func ExampleSkipList_Union() {
+	a, _ := skiplists.FromSorted([]int{1, 2, 3, 4, 5})
+	b, _ := skiplists.FromSorted([]int{4, 5, 6, 7})
+
+	union := a.Union(b)
+	intersection := a.Intersection(b)
+	difference := a.Difference(b)
+
+	fmt.Println(union.Slice(0, union.Len()))
+	fmt.Println(intersection.Slice(0, intersection.Len()))
+	fmt.Println(difference.Slice(0, difference.Len()))
+
+	// Output:
+	// [1 2 3 4 5 6 7]
+	// [4 5]
+	// [1 2 3]
+}
+
+func ExampleSkipList_Merge() {
+	type stock struct {
+		item  string
+		count int
+	}
+
+	byItem := func(a, b stock) int { return cmp.Compare(a.item, b.item) }
+
+	warehouse, _ := skiplists.FromSortedFunc([]stock{{"apple", 3}, {"pear", 5}}, byItem)
+	store, _ := skiplists.FromSortedFunc([]stock{{"apple", 2}, {"plum", 1}}, byItem)
+
+	total := warehouse.Merge(store, func(a, b stock) stock {
+		return stock{item: a.item, count: a.count + b.count}
+	})
+	fmt.Println(total.Slice(0, total.Len()))
+
+	// Output:
+	// [{apple 5} {pear 5} {plum 1}]
+}
+
+func ExampleCursor() {
+	list, _ := skiplists.FromSorted([]int{10, 20, 30, 40, 50, 60})
+
+	cursor := list.Cursor()
+	for cursor.Next() {
+		if cursor.Value() == 40 {
+			break
+		}
+	}
+	fmt.Println(cursor.Index(), cursor.Value())
+
+	cursor.Prev()
+	fmt.Println(cursor.Index(), cursor.Value())
+
+	// resume from any element
+	cursor.Seek(25)
+	fmt.Println(cursor.Index(), cursor.Value())
+
+	// remove elements while scanning
+	for cursor.Valid() {
+		if cursor.Value()%20 == 0 {
+			cursor.Delete()
+		} else {
+			cursor.Next()
+		}
+	}
+	fmt.Println(list.Slice(0, list.Len()))
+
+	cursor.SeekIndex(0)
+	fmt.Println(cursor.Value())
+
+	// Output:
+	// 3 40
+	// 2 30
+	// 2 30
+	// [10 20 30 50]
+	// 10
+}
+
+func ExampleSkipList_Clone() {
+	list, _ := skiplists.FromSorted([]int{1, 2, 3})
+
+	clone := list.Clone()
+	clone.Set(4)
+	clone.Unset(1)
+
+	fmt.Println(list.Slice(0, list.Len()))
+	fmt.Println(clone.Slice(0, clone.Len()))
+
+	// Output:
+	// [1 2 3]
+	// [2 3 4]
+}
+
+func ExampleIntervalList() {
+	// maintenance windows in hours of a day
+	windows := skiplists.NewIntervalList[int, string]()
+
+	windows.Set(0, 6, "backup")
+	windows.Set(2, 3, "rotate logs")
+	windows.Set(5, 9, "deploy")
+	windows.Set(12, 13, "lunch")
+
+	show := func(intervals []skiplists.Interval[int, string]) {
+		slices.SortFunc(intervals, func(a, b skiplists.Interval[int, string]) int {
+			return cmp.Compare(a.Lo, b.Lo)
+		})
+		for _, iv := range intervals {
+			fmt.Printf("[%d, %d] %s\n", iv.Lo, iv.Hi, iv.Value)
+		}
+	}
+
+	fmt.Println("at 5:")
+	show(windows.Stab(5))
+
+	fmt.Println("between 8 and 12:")
+	show(windows.Overlap(8, 12))
+
+	windows.Unset(0, 6)
+	fmt.Println("at 5, backup cancelled:")
+	show(windows.Stab(5))
+
+	// Output:
+	// at 5:
+	// [0, 6] backup
+	// [5, 9] deploy
+	// between 8 and 12:
+	// [5, 9] deploy
+	// [12, 13] lunch
+	// at 5, backup cancelled:
+	// [5, 9] deploy
+}
+
+func ExampleAggregateList() {
+	type event struct {
+		timestamp int
+		score     int
+	}
+
+	events := skiplists.NewAggregateListFunc(
+		func(a, b event) int { return cmp.Compare(a.timestamp, b.timestamp) },
+		skiplists.Monoid[event, int]{
+			Identity: 0,
+			Measure:  func(e event) int { return e.score },
+			Combine:  func(a, b int) int { return a + b },
+		},
+	)
+
+	for ts := 1; ts <= 10; ts++ {
+		events.Set(event{timestamp: ts, score: ts * 10})
+	}
+
+	// sum of the first 3 scores
+	fmt.Println(events.Aggregate(0, 3))
+
+	// rolling sum over a time window
+	fmt.Println(events.AggregateBetween(event{timestamp: 4}, event{timestamp: 6}, skiplists.Closed))
+
+	events.Unset(event{timestamp: 5})
+	fmt.Println(events.AggregateBetween(event{timestamp: 4}, event{timestamp: 6}, skiplists.Closed))
+
+	// Output:
+	// 60
+	// 150
+	// 100
+}
+
+func ExampleSortedSet() {
+	leaderboard := skiplists.NewSortedSet[string, int]()
+
+	leaderboard.Add("alice", 120)
+	leaderboard.Add("bob", 95)
+	leaderboard.Add("carol", 120)
+	leaderboard.Add("dave", 80)
+	leaderboard.IncrBy("dave", 50)
+
+	fmt.Println(leaderboard.Rank("alice"))
+	fmt.Println(leaderboard.Score("dave"))
+	fmt.Println(leaderboard.RangeByScore(100, 130, skiplists.Closed))
+	fmt.Println(leaderboard.RangeByRank(0, 2))
+
+	fmt.Println(leaderboard.PopMax())
+	fmt.Println(leaderboard.PopMin())
+	fmt.Println(leaderboard.Len())
+
+	// Output:
+	// 1 true
+	// 130 true
+	// [{alice 120} {carol 120} {dave 130}]
+	// [{bob 95} {alice 120}]
+	// {dave 130} true
+	// {bob 95} true
+	// 2
+}
+
+func ExampleSkipList_UnmarshalJSON() {
+	list := skiplists.New[int]()
+	list.Set(3).Set(1).Set(2)
+
+	data, _ := json.Marshal(list)
+	fmt.Println(string(data))
+
+	// the cmp function is provided by the receiver
+	decoded := skiplists.New[int]()
+	err := json.Unmarshal(data, decoded)
+	fmt.Println(decoded.Slice(0, decoded.Len()), err)
+
+	err = json.Unmarshal([]byte("[3, 2, 1]"), decoded)
+	fmt.Println(errors.Is(err, skiplists.ErrUnsorted))
+
+	// Output:
+	// [1,2,3]
+	// [1 2 3] <nil>
+	// true
+}
+
+func ExampleSkipList_MarshalJSON() {
+	type player struct {
+		Name   string
+		Scores skiplists.SkipList[int] // never initialized
+	}
+
+	data, err := json.Marshal(&player{Name: "alice"})
+	fmt.Println(string(data), err)
+
+	// but it cannot be decoded into without a cmp function
+	err = json.Unmarshal(data, &player{})
+	fmt.Println(errors.Is(err, skiplists.ErrNoCmp))
+
+	// Output:
+	// {"Name":"alice","Scores":[]} <nil>
+	// true
+}
+
+func ExampleSkipList_GobDecode() {
+	type snapshot struct {
+		Name   string
+		Scores *skiplists.SkipList[float64]
+	}
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(snapshot{
+		Name:   "round 1",
+		Scores: skiplists.New[float64]().Set(9.5).Set(7.25).Set(8),
+	})
+	fmt.Println(err)
+
+	decoded := snapshot{Scores: skiplists.New[float64]()}
+	err = gob.NewDecoder(&buf).Decode(&decoded)
+	fmt.Println(decoded.Name, decoded.Scores.Slice(0, decoded.Scores.Len()), err)
+
+	// Output:
+	// <nil>
+	// round 1 [7.25 8 9.5] <nil>
+}
+
+func ExampleSkipList_Stats() {
+	list := skiplists.New[int](skiplists.SetRandSource(rand.NewSource(1)))
+	for i := 0; i < 1000; i++ {
+		list.Set(i)
+	}
+
+	stats := list.Stats()
+	fmt.Println(stats.Len, stats.Nodes[0])
+	fmt.Println(stats.Level == len(stats.Nodes))
+	fmt.Println(stats.AvgSearchPath < 2*float64(stats.Level))
+
+	// Output:
+	// 1000 1000
+	// true
+	// true
+}
+
+func ExampleSkipList_Rebalance() {
+	list := skiplists.New[int](skiplists.SetRandSource(rand.NewSource(1)))
+	for i := 0; i < 1000; i++ {
+		list.Set(i)
+	}
+	list.RemoveRange(0, 990)
+
+	list.Rebalance()
+	fmt.Println(list.Stats().Nodes)
+
+	// Output:
+	// [10 5 2 1]
+}
+
+func ExampleSkipList_Options() {
+	list := skiplists.New[int](skiplists.SetLogP(2), skiplists.SetDuplicates(true))
+
+	opt := list.Options()
+	fmt.Println(opt.LogP, opt.Duplicates, opt.NoRanks)
+
+	// Output:
+	// 2 true false
+}
+
+func ExampleSkipList_Validate() {
+	list := skiplists.New[int]()
+	for i := 0; i < 1000; i++ {
+		list.Set(rand.Intn(100))
+		list.Unset(rand.Intn(100))
+	}
+
+	fmt.Println(list.Validate())
+
+	// Output:
+	// <nil>
+}
@@ -0,0 +1,703 @@
+package skiplists_test
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/houz42/abstract/internal/modeltest"
+	"github.com/houz42/abstract/skiplists"
+)
+
+func FuzzSkipList(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(false, true, seed)
+		f.Add(true, true, seed)
+		f.Add(false, false, seed)
+		f.Add(true, false, seed)
+	}
+
+	f.Fuzz(func(t *testing.T, duplicates, ranks bool, data []byte) {
+		// a small range of values, to hit the existing ones often
+		const values = 64
+
+		newList := func() *skiplists.SkipList[int] {
+			return skiplists.New[int](
+				skiplists.SetDuplicates(duplicates),
+				skiplists.SetRanks(ranks),
+				skiplists.SetRandSource(rand.NewSource(1)),
+			)
+		}
+		sl := newList()
+		model := &modeltest.Sorted[int]{Duplicates: duplicates}
+
+		// indexes of the first element not less than v, and the first one greater than v
+		lowerBound := func(v int) int { i, _ := slices.BinarySearch(model.Values, v); return i }
+		upperBound := func(v int) int { return lowerBound(v + 1) }
+
+		// between returns the index range of the elements between lo and hi
+		between := func(lo, hi int, b skiplists.Bounds) (int, int) {
+			i, j := lowerBound(lo), upperBound(hi)
+			if b&skiplists.LeftOpen != 0 {
+				i = upperBound(lo)
+			}
+			if b&skiplists.RightOpen != 0 {
+				j = lowerBound(hi)
+			}
+			return i, max(i, j)
+		}
+
+		// found checks the result of a query against the element at index i of the model, if any
+		found := func(what string, got int, ok bool, i int) error {
+			want, wantOK := 0, i >= 0 && i < len(model.Values)
+			if wantOK {
+				want = model.Values[i]
+			}
+			if err := modeltest.Equal(what+" found", ok, wantOK); err != nil {
+				return err
+			}
+			return modeltest.Equal(what, got, want)
+		}
+
+		ops := []modeltest.Op{
+			{Name: "Set", Run: func(arg int) error {
+				sl.Set(arg % values)
+				model.Set(arg % values)
+				return nil
+			}},
+			{Name: "Unset", Run: func(arg int) error {
+				sl.Unset(arg % values)
+				model.Unset(arg % values)
+				return nil
+			}},
+			{Name: "UnsetAll", Run: func(arg int) error {
+				sl.UnsetAll(arg % values)
+				for model.Count(arg%values) > 0 {
+					model.Unset(arg % values)
+				}
+				return nil
+			}},
+			{Name: "RemoveAt", Run: func(arg int) error {
+				if len(model.Values) == 0 {
+					return nil
+				}
+				i := arg % len(model.Values)
+				sl.RemoveAt(i)
+				model.Values = append(model.Values[:i], model.Values[i+1:]...)
+				return nil
+			}},
+			{Name: "RemoveRange", Run: func(arg int) error {
+				i := arg % (len(model.Values) + 1)
+				j := i + arg/256%(len(model.Values)-i+1)
+				sl.RemoveRange(i, j)
+				model.Values = append(model.Values[:i], model.Values[j:]...)
+				return nil
+			}},
+			{Name: "Clone", Run: func(int) error {
+				sl = sl.Clone()
+				return nil
+			}},
+			{Name: "Rebalance", Run: func(int) error {
+				sl.Rebalance()
+				return nil
+			}},
+			{Name: "Get", Run: func(arg int) error {
+				_, got := sl.Get(arg % values)
+				_, want := model.Index(arg % values)
+				return modeltest.Equal(fmt.Sprintf("Get(%d)", arg%values), got, want)
+			}},
+			{Name: "IndexOf", Run: func(arg int) error {
+				got, _ := sl.IndexOf(arg % values)
+				want, _ := model.Index(arg % values)
+				return modeltest.Equal(fmt.Sprintf("IndexOf(%d)", arg%values), got, want)
+			}},
+			{Name: "Count", Run: func(arg int) error {
+				return modeltest.Equal(fmt.Sprintf("Count(%d)", arg%values), sl.Count(arg%values), model.Count(arg%values))
+			}},
+			{Name: "CountLess", Run: func(arg int) error {
+				v := arg % values
+				return modeltest.Equal(fmt.Sprintf("CountLess(%d)", v), sl.CountLess(v), lowerBound(v))
+			}},
+			{Name: "Neighbors", Run: func(arg int) error {
+				v := arg % values
+				got, ok := sl.Floor(v)
+				if err := found(fmt.Sprintf("Floor(%d)", v), got, ok, upperBound(v)-1); err != nil {
+					return err
+				}
+				got, ok = sl.Ceiling(v)
+				if err := found(fmt.Sprintf("Ceiling(%d)", v), got, ok, lowerBound(v)); err != nil {
+					return err
+				}
+				got, ok = sl.Lower(v)
+				if err := found(fmt.Sprintf("Lower(%d)", v), got, ok, lowerBound(v)-1); err != nil {
+					return err
+				}
+				got, ok = sl.Higher(v)
+				return found(fmt.Sprintf("Higher(%d)", v), got, ok, upperBound(v))
+			}},
+			{Name: "Range", Run: func(arg int) error {
+				lo, hi, b := arg%values, arg/64%values, skiplists.Bounds(arg/4096%4)
+				i, j := between(lo, hi, b)
+				what := fmt.Sprintf("(%d, %d, %d)", lo, hi, b)
+				if err := modeltest.Equal("CountBetween"+what, sl.CountBetween(lo, hi, b), j-i); err != nil {
+					return err
+				}
+				return modeltest.EqualSlices("Range"+what, sl.Range(lo, hi, b), model.Values[i:j])
+			}},
+			{Name: "RemoveBetween", Run: func(arg int) error {
+				lo, hi, b := arg%values, arg/64%values, skiplists.Bounds(arg/4096%4)
+				i, j := between(lo, hi, b)
+				sl.RemoveBetween(lo, hi, b)
+				model.Values = slices.Delete(model.Values, i, j)
+				return nil
+			}},
+			{Name: "Reverse", Run: func(int) error {
+				got := sl.Reverse()
+				want := slices.Clone(model.Values)
+				slices.Reverse(want)
+				if err := got.Validate(); err != nil {
+					return fmt.Errorf("Reverse(): %w", err)
+				}
+				return modeltest.EqualSlices("Reverse()", got.Slice(0, got.Len()), want)
+			}},
+			{Name: "Combine", Run: func(arg int) error {
+				// the other list may allow duplicates or not, regardless of sl
+				otherDuplicates := arg/1024%2 == 1
+				other := skiplists.New[int](skiplists.SetDuplicates(otherDuplicates), skiplists.SetRanks(ranks))
+				otherModel := &modeltest.Sorted[int]{Duplicates: otherDuplicates}
+				for k := 0; k < arg%8; k++ {
+					other.Set((arg + k/2*7) % values)
+					otherModel.Set((arg + k/2*7) % values)
+				}
+
+				// the number of each element in the result
+				var count func(a, b int) int
+				switch arg / 256 % 3 {
+				case 0:
+					sl, count = sl.Union(other), func(a, b int) int { return max(a, b) }
+				case 1:
+					sl, count = sl.Intersection(other), func(a, b int) int { return min(a, b) }
+				case 2:
+					sl, count = sl.Difference(other), func(a, b int) int { return max(a-b, 0) }
+				}
+
+				var want []int
+				for v := 0; v < values; v++ {
+					n := count(model.Count(v), otherModel.Count(v))
+					if !duplicates {
+						n = min(n, 1)
+					}
+					for ; n > 0; n-- {
+						want = append(want, v)
+					}
+				}
+				model.Values = want
+				return nil
+			}},
+			{Name: "Cursor", Run: func(arg int) error {
+				c := sl.Cursor()
+				v := arg % values
+				pos := lowerBound(v)
+				check := func(what string, valid bool) error {
+					if err := modeltest.Equal(what+" valid", valid, pos >= 0 && pos < len(model.Values)); err != nil {
+						return err
+					}
+					if err := modeltest.Equal(what+" Index()", c.Index(), pos); err != nil {
+						return err
+					}
+					if !valid {
+						return nil
+					}
+					return modeltest.Equal(what+" Value()", c.Value(), model.Values[pos])
+				}
+
+				if err := check(fmt.Sprintf("Seek(%d)", v), c.Seek(v)); err != nil {
+					return err
+				}
+				steps := arg / 256 % 8
+				for k := 0; k < steps; k++ {
+					pos = min(pos+1, len(model.Values))
+					if err := check("Next()", c.Next()); err != nil {
+						return err
+					}
+				}
+				for k := 0; k < 2*steps; k++ {
+					pos = max(pos-1, -1)
+					if err := check("Prev()", c.Prev()); err != nil {
+						return err
+					}
+				}
+				return nil
+			}},
+			{Name: "CursorDelete", Run: func(arg int) error {
+				if len(model.Values) == 0 {
+					return nil
+				}
+				c := sl.Cursor()
+				i := arg % len(model.Values)
+				c.SeekIndex(i)
+				valid := c.Delete()
+				model.Values = slices.Delete(model.Values, i, i+1)
+				if err := modeltest.Equal("Delete() valid", valid, i < len(model.Values)); err != nil {
+					return err
+				}
+				if !valid {
+					return nil
+				}
+				return modeltest.Equal("Value() after Delete()", c.Value(), model.Values[i])
+			}},
+			{Name: "Encode", Run: func(arg int) error {
+				decoded := newList()
+				if arg%2 == 0 {
+					data, err := json.Marshal(sl)
+					if err != nil {
+						return err
+					}
+					if err := json.Unmarshal(data, decoded); err != nil {
+						return err
+					}
+				} else {
+					data, err := sl.MarshalBinary()
+					if err != nil {
+						return err
+					}
+					if err := decoded.UnmarshalBinary(data); err != nil {
+						return err
+					}
+				}
+				sl = decoded
+				return nil
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := sl.Validate(); err != nil {
+				return err
+			}
+			return modeltest.EqualSlices("elements", sl.Slice(0, sl.Len()), model.Values)
+		})
+	})
+}
+
+func FuzzMap(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		const keys = 64
+
+		m := skiplists.NewMap[int, int](skiplists.SetRandSource(rand.NewSource(1)))
+		model := make(map[int]int)
+
+		ops := []modeltest.Op{
+			{Name: "Put", Run: func(arg int) error {
+				m.Put(arg%keys, arg)
+				model[arg%keys] = arg
+				return nil
+			}},
+			{Name: "Delete", Run: func(arg int) error {
+				m.Delete(arg % keys)
+				delete(model, arg%keys)
+				return nil
+			}},
+			{Name: "Get", Run: func(arg int) error {
+				got, _ := m.Get(arg % keys)
+				return modeltest.Equal(fmt.Sprintf("Get(%d)", arg%keys), got, model[arg%keys])
+			}},
+			{Name: "Has", Run: func(arg int) error {
+				_, want := model[arg%keys]
+				return modeltest.Equal(fmt.Sprintf("Has(%d)", arg%keys), m.Has(arg%keys), want)
+			}},
+			{Name: "Clone", Run: func(int) error {
+				m = m.Clone()
+				return nil
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", m.Len(), len(model)); err != nil {
+				return err
+			}
+			prev := -1
+			for i := 0; i < m.Len(); i++ {
+				k, v := m.At(i)
+				if k <= prev {
+					return fmt.Errorf("At(%d): key %d is out of order", i, k)
+				}
+				if err := modeltest.Equal(fmt.Sprintf("At(%d)", i), v, model[k]); err != nil {
+					return err
+				}
+				prev = k
+			}
+			return nil
+		})
+	})
+}
+
+func FuzzAggregateList(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(false, seed)
+		f.Add(true, seed)
+	}
+
+	f.Fuzz(func(t *testing.T, duplicates bool, data []byte) {
+		const keys = 64
+
+		// items are ordered by their keys, and overwritten ones are told by their weights
+		type item struct{ key, weight int }
+
+		// concatenation is not commutative, so the aggregations must be combined in order
+		al := skiplists.NewAggregateListFunc(
+			func(a, b item) int { return cmp.Compare(a.key, b.key) },
+			skiplists.Monoid[item, string]{
+				Identity: "",
+				Measure:  func(x item) string { return fmt.Sprintf("%d:%d,", x.key, x.weight) },
+				Combine:  func(a, b string) string { return a + b },
+			},
+			skiplists.SetDuplicates(duplicates),
+			skiplists.SetRandSource(rand.NewSource(1)),
+		)
+		var model []item
+
+		aggregate := func(items []item) string {
+			var sb strings.Builder
+			for _, x := range items {
+				fmt.Fprintf(&sb, "%d:%d,", x.key, x.weight)
+			}
+			return sb.String()
+		}
+		// lowerBound returns index of the first item with key not less than k
+		lowerBound := func(k int) int {
+			i, _ := slices.BinarySearchFunc(model, k, func(x item, k int) int { return cmp.Compare(x.key, k) })
+			return i
+		}
+
+		ops := []modeltest.Op{
+			{Name: "Set", Run: func(arg int) error {
+				x := item{key: arg % keys, weight: arg / 256}
+				al.Set(x)
+				i := lowerBound(x.key + 1)
+				if !duplicates && i > 0 && model[i-1].key == x.key {
+					model[i-1] = x
+				} else {
+					model = slices.Insert(model, i, x)
+				}
+				return nil
+			}},
+			{Name: "Unset", Run: func(arg int) error {
+				al.Unset(item{key: arg % keys})
+				if i := lowerBound(arg % keys); i < len(model) && model[i].key == arg%keys {
+					model = slices.Delete(model, i, i+1)
+				}
+				return nil
+			}},
+			{Name: "RemoveAt", Run: func(arg int) error {
+				if len(model) == 0 {
+					return nil
+				}
+				i := arg % len(model)
+				al.RemoveAt(i)
+				model = slices.Delete(model, i, i+1)
+				return nil
+			}},
+			{Name: "Rebalance", Run: func(int) error {
+				al.Rebalance()
+				return nil
+			}},
+			{Name: "Aggregate", Run: func(arg int) error {
+				i := arg % (len(model) + 1)
+				j := i + arg/256%(len(model)-i+1)
+				return modeltest.Equal(fmt.Sprintf("Aggregate(%d, %d)", i, j), al.Aggregate(i, j), aggregate(model[i:j]))
+			}},
+			{Name: "AggregateBetween", Run: func(arg int) error {
+				lo, hi, b := arg%keys, arg/64%keys, skiplists.Bounds(arg/4096%4)
+				i, j := lowerBound(lo), lowerBound(hi+1)
+				if b&skiplists.LeftOpen != 0 {
+					i = lowerBound(lo + 1)
+				}
+				if b&skiplists.RightOpen != 0 {
+					j = lowerBound(hi)
+				}
+				want := ""
+				if i < j {
+					want = aggregate(model[i:j])
+				}
+				got := al.AggregateBetween(item{key: lo}, item{key: hi}, b)
+				return modeltest.Equal(fmt.Sprintf("AggregateBetween(%d, %d, %d)", lo, hi, b), got, want)
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", al.Len(), len(model)); err != nil {
+				return err
+			}
+			for i, x := range model {
+				if err := modeltest.Equal(fmt.Sprintf("At(%d)", i), al.At(i), x); err != nil {
+					return err
+				}
+			}
+			return modeltest.Equal("Aggregate(0, Len())", al.Aggregate(0, al.Len()), aggregate(model))
+		})
+	})
+}
+
+func FuzzIntervalList(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	const points = 64
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		il := skiplists.NewIntervalList[int, int](skiplists.SetRandSource(rand.NewSource(1)))
+		var model []skiplists.Interval[int, int]
+
+		// interval decodes an interval within [0, points) from arg, mostly short ones to have more endpoints
+		interval := func(arg int) (int, int) {
+			lo := arg % points
+			return lo, min(lo+arg/64%16, points-1)
+		}
+		index := func(lo, hi int) int {
+			return slices.IndexFunc(model, func(iv skiplists.Interval[int, int]) bool { return iv.Lo == lo && iv.Hi == hi })
+		}
+
+		// sorted sorts the intervals found, which are returned in no particular order
+		sorted := func(intervals []skiplists.Interval[int, int]) []skiplists.Interval[int, int] {
+			slices.SortFunc(intervals, func(a, b skiplists.Interval[int, int]) int {
+				if c := cmp.Compare(a.Lo, b.Lo); c != 0 {
+					return c
+				}
+				return cmp.Compare(a.Hi, b.Hi)
+			})
+			return intervals
+		}
+		brute := func(lo, hi int) []skiplists.Interval[int, int] {
+			var found []skiplists.Interval[int, int]
+			for _, iv := range model {
+				if iv.Lo <= hi && lo <= iv.Hi {
+					found = append(found, iv)
+				}
+			}
+			return sorted(found)
+		}
+
+		ops := []modeltest.Op{
+			{Name: "Set", Run: func(arg int) error {
+				lo, hi := interval(arg)
+				il.Set(lo, hi, arg)
+				if k := index(lo, hi); k >= 0 {
+					model[k].Value = arg
+				} else {
+					model = append(model, skiplists.Interval[int, int]{Lo: lo, Hi: hi, Value: arg})
+				}
+				return nil
+			}},
+			{Name: "Unset", Run: func(arg int) error {
+				lo, hi := interval(arg)
+				il.Unset(lo, hi)
+				if k := index(lo, hi); k >= 0 {
+					model = slices.Delete(model, k, k+1)
+				}
+				return nil
+			}},
+			{Name: "Get", Run: func(arg int) error {
+				lo, hi := interval(arg)
+				got, ok := il.Get(lo, hi)
+				want, wantOK := 0, false
+				if k := index(lo, hi); k >= 0 {
+					want, wantOK = model[k].Value, true
+				}
+				if err := modeltest.Equal(fmt.Sprintf("Get(%d, %d) found", lo, hi), ok, wantOK); err != nil {
+					return err
+				}
+				return modeltest.Equal(fmt.Sprintf("Get(%d, %d)", lo, hi), got, want)
+			}},
+			{Name: "Stab", Run: func(arg int) error {
+				p := arg % points
+				return modeltest.EqualSlices(fmt.Sprintf("Stab(%d)", p), sorted(il.Stab(p)), brute(p, p))
+			}},
+			{Name: "Overlap", Run: func(arg int) error {
+				lo, hi := interval(arg)
+				return modeltest.EqualSlices(fmt.Sprintf("Overlap(%d, %d)", lo, hi), sorted(il.Overlap(lo, hi)), brute(lo, hi))
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", il.Len(), len(model)); err != nil {
+				return err
+			}
+			// markers left on wrong links show up as wrong results of stabbing somewhere
+			for p := 0; p < points; p++ {
+				if err := modeltest.EqualSlices(fmt.Sprintf("Stab(%d)", p), sorted(il.Stab(p)), brute(p, p)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func FuzzSortedSet(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	const (
+		members = 32
+		scores  = 16
+	)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ss := skiplists.NewSortedSet[int, int](skiplists.SetRandSource(rand.NewSource(1)))
+		model := make(map[int]int) // scores of the members
+
+		// ranked returns the members ordered by score, then by member
+		ranked := func() []skiplists.Scored[int, int] {
+			all := make([]skiplists.Scored[int, int], 0, len(model))
+			for m, s := range model {
+				all = append(all, skiplists.Scored[int, int]{Member: m, Score: s})
+			}
+			slices.SortFunc(all, func(a, b skiplists.Scored[int, int]) int {
+				if c := cmp.Compare(a.Score, b.Score); c != 0 {
+					return c
+				}
+				return cmp.Compare(a.Member, b.Member)
+			})
+			return all
+		}
+		// pop checks a popped member against the lowest one, or the highest one if highest is true
+		pop := func(what string, got skiplists.Scored[int, int], ok bool, highest bool) error {
+			all := ranked()
+			if err := modeltest.Equal(what+" found", ok, len(all) > 0); err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			want := all[0]
+			if highest {
+				want = all[len(all)-1]
+			}
+			delete(model, want.Member)
+			return modeltest.Equal(what, got, want)
+		}
+
+		ops := []modeltest.Op{
+			{Name: "Add", Run: func(arg int) error {
+				m, s := arg%members, arg/256%scores
+				ss.Add(m, s)
+				model[m] = s
+				return nil
+			}},
+			{Name: "IncrBy", Run: func(arg int) error {
+				m, delta := arg%members, arg/256%scores-scores/2
+				model[m] += delta
+				return modeltest.Equal(fmt.Sprintf("IncrBy(%d, %d)", m, delta), ss.IncrBy(m, delta), model[m])
+			}},
+			{Name: "Remove", Run: func(arg int) error {
+				ss.Remove(arg % members)
+				delete(model, arg%members)
+				return nil
+			}},
+			{Name: "Score", Run: func(arg int) error {
+				m := arg % members
+				got, ok := ss.Score(m)
+				want, wantOK := model[m]
+				if err := modeltest.Equal(fmt.Sprintf("Score(%d) found", m), ok, wantOK); err != nil {
+					return err
+				}
+				return modeltest.Equal(fmt.Sprintf("Score(%d)", m), got, want)
+			}},
+			{Name: "Rank", Run: func(arg int) error {
+				m := arg % members
+				got, ok := ss.Rank(m)
+				want := slices.IndexFunc(ranked(), func(s skiplists.Scored[int, int]) bool { return s.Member == m })
+				if err := modeltest.Equal(fmt.Sprintf("Rank(%d) found", m), ok, want >= 0); err != nil {
+					return err
+				}
+				return modeltest.Equal(fmt.Sprintf("Rank(%d)", m), got, want)
+			}},
+			{Name: "RangeByScore", Run: func(arg int) error {
+				lo, hi, b := arg%scores-scores/2, arg/16%scores, skiplists.Bounds(arg/4096%4)
+				var want []skiplists.Scored[int, int]
+				for _, s := range ranked() {
+					if (s.Score > lo || s.Score == lo && b&skiplists.LeftOpen == 0) &&
+						(s.Score < hi || s.Score == hi && b&skiplists.RightOpen == 0) {
+						want = append(want, s)
+					}
+				}
+				return modeltest.EqualSlices(fmt.Sprintf("RangeByScore(%d, %d, %d)", lo, hi, b), ss.RangeByScore(lo, hi, b), want)
+			}},
+			{Name: "PopMin", Run: func(int) error {
+				got, ok := ss.PopMin()
+				return pop("PopMin()", got, ok, false)
+			}},
+			{Name: "PopMax", Run: func(int) error {
+				got, ok := ss.PopMax()
+				return pop("PopMax()", got, ok, true)
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", ss.Len(), len(model)); err != nil {
+				return err
+			}
+			return modeltest.EqualSlices("RangeByRank(0, Len())", ss.RangeByRank(0, ss.Len()), ranked())
+		})
+	})
+}
+
+// FuzzConcurrent runs the operations one by one, concurrency is covered by the tests with the race detector.
+func FuzzConcurrent(f *testing.F) {
+	for _, seed := range modeltest.Seeds(500) {
+		f.Add(seed)
+	}
+
+	const values = 64
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c := skiplists.NewConcurrent[int](skiplists.SetRandSource(rand.NewSource(1)))
+		model := &modeltest.Sorted[int]{}
+
+		ops := []modeltest.Op{
+			{Name: "Set", Run: func(arg int) error {
+				c.Set(arg % values)
+				model.Set(arg % values)
+				return nil
+			}},
+			{Name: "Unset", Run: func(arg int) error {
+				c.Unset(arg % values)
+				model.Unset(arg % values)
+				return nil
+			}},
+			{Name: "Get", Run: func(arg int) error {
+				v := arg % values
+				got, ok := c.Get(v)
+				_, want := model.Index(v)
+				if err := modeltest.Equal(fmt.Sprintf("Get(%d) found", v), ok, want); err != nil {
+					return err
+				}
+				if !ok {
+					return nil
+				}
+				return modeltest.Equal(fmt.Sprintf("Get(%d)", v), got, v)
+			}},
+		}
+
+		modeltest.Drive(t, data, ops, func() error {
+			if err := modeltest.Equal("Len()", c.Len(), len(model.Values)); err != nil {
+				return err
+			}
+			sl := c.Snapshot()
+			if err := sl.Validate(); err != nil {
+				return fmt.Errorf("Snapshot(): %w", err)
+			}
+			return modeltest.EqualSlices("Snapshot()", sl.Slice(0, sl.Len()), model.Values)
+		})
+	})
+}
@@ -0,0 +1,294 @@
+package skiplists
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/houz42/abstract/sets"
+)
+
+// IntervalList is an [interval skip list], which stores closed intervals with associated values,
+// and finds the intervals containing a point (a.k.a. stabbing query) or overlapping a range.
+//
+// Endpoints of the intervals are kept in a [SkipList].
+// Each interval puts markers on a chain of links covering it from its lower endpoint to its upper one,
+// choosing the highest links available, so any interval containing a point is marked on exactly one link
+// along the search path of the point.
+// Thus a stabbing query is answered in expected O(log n + k) time, where k is the number of intervals found.
+//
+// An IntervalList is not safe for concurrent use by multiple goroutines.
+//
+// [interval skip list]: https://www.cise.ufl.edu/tr/DOC/REP-1991-017.pdf
+type IntervalList[K, V any] struct {
+	list *SkipList[endpoint[K, V]]
+	size int // number of intervals
+	cmp  func(a, b K) int
+}
+
+// Interval is a closed interval [Lo, Hi] with a value associated.
+type Interval[K, V any] struct {
+	Lo, Hi K
+	Value  V
+}
+
+// endpoint is an element in the SkipList of an IntervalList,
+// along with the intervals marked on the links from its node on each level.
+type endpoint[K, V any] struct {
+	key     K
+	markers []sets.Set[*interval[K, V]] // intervals whose chains contain the link on each level
+
+	// intervals start or end at this endpoint
+	starts, ends []*interval[K, V]
+}
+
+type interval[K, V any] struct {
+	Interval[K, V]
+	lo, hi *node[endpoint[K, V]]
+	marks  []mark[K, V]
+}
+
+// mark is a link starting from node on level.
+type mark[K, V any] struct {
+	node  *node[endpoint[K, V]]
+	level int
+}
+
+// NewIntervalList returns an [IntervalList] of any ordered endpoints.
+func NewIntervalList[K cmp.Ordered, V any](options ...Option) *IntervalList[K, V] {
+	return NewIntervalListFunc[K, V](cmp.Compare, options...)
+}
+
+// NewIntervalListFunc returns an IntervalList of any endpoint type when a custom cmp function is provided.
+// The `cmp` function follows the same rules as the one passed to [NewFunc].
+// Endpoints in an IntervalList are always unique and ranked, so options [SetDuplicates] and [SetRanks] have no effect.
+func NewIntervalListFunc[K, V any](cmp func(a, b K) int, options ...Option) *IntervalList[K, V] {
+	options = append(options[:len(options):len(options)], SetDuplicates(false), SetRanks(true))
+	return &IntervalList[K, V]{
+		list: NewFunc(func(a, b endpoint[K, V]) int { return cmp(a.key, b.key) }, options...),
+		cmp:  cmp,
+	}
+}
+
+// Len returns number of intervals in the IntervalList.
+func (il *IntervalList[K, V]) Len() int { return il.size }
+
+// Get returns the value associated with the interval [lo, hi] and true if it is in the IntervalList,
+// otherwise zero value of type V and false.
+func (il *IntervalList[K, V]) Get(lo, hi K) (V, bool) {
+	if iv := il.find(lo, hi); iv != nil {
+		return iv.Value, true
+	}
+	var v V
+	return v, false
+}
+
+// Set inserts the interval [lo, hi] with its associated value into the IntervalList.
+// If the interval is already in, the value will be overwritten.
+// It panics if lo is greater than hi.
+func (il *IntervalList[K, V]) Set(lo, hi K, val V) *IntervalList[K, V] {
+	if il.cmp(lo, hi) > 0 {
+		panic(fmt.Errorf("skiplists: invalid interval: lower endpoint %v is greater than the upper one %v", lo, hi))
+	}
+
+	if iv := il.find(lo, hi); iv != nil {
+		iv.Value = val
+		return il
+	}
+
+	iv := &interval[K, V]{Interval: Interval[K, V]{Lo: lo, Hi: hi, Value: val}}
+	iv.lo = il.endpoint(lo)
+	iv.hi = il.endpoint(hi)
+	iv.lo.val.starts = append(iv.lo.val.starts, iv)
+	iv.hi.val.ends = append(iv.hi.val.ends, iv)
+	il.place(iv)
+	il.size++
+
+	return il
+}
+
+// Unset removes the interval [lo, hi] from the IntervalList.
+// If the interval is not found, nothing happens.
+func (il *IntervalList[K, V]) Unset(lo, hi K) *IntervalList[K, V] {
+	iv := il.find(lo, hi)
+	if iv == nil {
+		return il
+	}
+
+	il.unplace(iv)
+	iv.lo.val.starts = removeInterval(iv.lo.val.starts, iv)
+	iv.hi.val.ends = removeInterval(iv.hi.val.ends, iv)
+	il.release(iv.lo)
+	if iv.hi != iv.lo {
+		il.release(iv.hi)
+	}
+	il.size--
+
+	return il
+}
+
+// Stab returns all the intervals containing the point p, in no particular order.
+// The complexity is expected O(log n + k), where k is the number of intervals returned.
+func (il *IntervalList[K, V]) Stab(p K) []Interval[K, V] {
+	found, _ := il.stab(p, nil)
+	return found
+}
+
+// Overlap returns all the intervals overlapping the closed range [lo, hi], in no particular order.
+// The complexity is expected O(log n + m + k),
+// where m is the number of endpoints in the range, and k is the number of intervals returned.
+func (il *IntervalList[K, V]) Overlap(lo, hi K) []Interval[K, V] {
+	// intervals containing lo, and the ones starting in (lo, hi]
+	found, nd := il.stab(lo, nil)
+	for nd = nd.tower[0].next; nd != nil && il.cmp(nd.val.key, hi) <= 0; nd = nd.tower[0].next {
+		for _, iv := range nd.val.starts {
+			found = append(found, iv.Interval)
+		}
+	}
+	return found
+}
+
+// stab appends the intervals containing p to found,
+// and returns them along with the last endpoint not greater than p, or the head if there is no such one.
+func (il *IntervalList[K, V]) stab(p K, found []Interval[K, V]) ([]Interval[K, V], *node[endpoint[K, V]]) {
+	head := il.list.head
+	nd := head
+	for level := il.list.level - 1; level >= 0; level-- {
+		for nd.tower[level].next != nil && il.cmp(nd.tower[level].next.val.key, p) <= 0 {
+			nd = nd.tower[level].next
+		}
+
+		// the link covers [nd.key, nd.next.key), which contains p
+		if nd != head && nd.tower[level].next != nil {
+			for iv := range nd.val.markers[level] {
+				found = append(found, iv.Interval)
+			}
+		}
+	}
+
+	// intervals end at p are not covered by any of the half-open links
+	if nd != head && il.cmp(nd.val.key, p) == 0 {
+		for _, iv := range nd.val.ends {
+			found = append(found, iv.Interval)
+		}
+	}
+
+	return found, nd
+}
+
+// find returns the interval [lo, hi] or nil if it is not found.
+func (il *IntervalList[K, V]) find(lo, hi K) *interval[K, V] {
+	nd, _ := il.list.seek(endpoint[K, V]{key: lo}, false)
+	nd = nd.tower[0].next
+	if nd == nil || il.cmp(nd.val.key, lo) != 0 {
+		return nil
+	}
+
+	for _, iv := range nd.val.starts {
+		if il.cmp(iv.Hi, hi) == 0 {
+			return iv
+		}
+	}
+	return nil
+}
+
+// endpoint returns the node of key, which is inserted if it is not found.
+func (il *IntervalList[K, V]) endpoint(key K) *node[endpoint[K, V]] {
+	ep := endpoint[K, V]{key: key}
+	nd, i := il.list.seek(ep, true)
+	if nd != il.list.head && il.cmp(nd.val.key, key) == 0 {
+		return nd
+	}
+
+	var updatesBuf [stackLevels]*node[endpoint[K, V]]
+	updates := il.list.predecessors(i+1, buffer(updatesBuf[:], il.list.level))
+
+	il.list.Set(ep)
+	nd = updates[0].tower[0].next
+	nd.val.markers = make([]sets.Set[*interval[K, V]], len(nd.tower))
+
+	// the links split by the new node are not valid for the intervals marked on them any more,
+	// while the ones on the new levels start from the head, which are never marked
+	for iv := range il.affected(updates[:min(len(nd.tower), len(updates))]) {
+		il.unplace(iv)
+		il.place(iv)
+	}
+
+	return nd
+}
+
+// release removes the endpoint if there are no intervals start or end at it.
+func (il *IntervalList[K, V]) release(nd *node[endpoint[K, V]]) {
+	if len(nd.val.starts) > 0 || len(nd.val.ends) > 0 {
+		return
+	}
+
+	_, pos := il.list.seek(nd.val, false)
+	var updatesBuf [stackLevels]*node[endpoint[K, V]]
+	updates := il.list.predecessors(pos+1, buffer(updatesBuf[:], il.list.level))
+
+	// intervals passing through the endpoint must be marked on a link ending at it,
+	// and the links to be merged are not valid for them any more
+	affected := il.affected(updates[:len(nd.tower)])
+	for iv := range affected {
+		il.unplace(iv)
+	}
+
+	il.list.RemoveAt(pos + 1)
+
+	for iv := range affected {
+		il.place(iv)
+	}
+}
+
+// affected returns the intervals marked on the links starting from updates on each level.
+func (il *IntervalList[K, V]) affected(updates []*node[endpoint[K, V]]) sets.Set[*interval[K, V]] {
+	affected := sets.New[*interval[K, V]]()
+	for level, nd := range updates {
+		if nd == il.list.head {
+			continue
+		}
+		for iv := range nd.val.markers[level] {
+			affected.Set(iv)
+		}
+	}
+	return affected
+}
+
+// place marks the interval on a chain of links from its lower endpoint to its upper one,
+// the highest link not beyond the upper endpoint is taken at each step.
+func (il *IntervalList[K, V]) place(iv *interval[K, V]) {
+	for nd := iv.lo; nd != iv.hi; {
+		level := len(nd.tower) - 1
+		for nd.tower[level].next == nil || il.cmp(nd.tower[level].next.val.key, iv.Hi) > 0 {
+			level--
+		}
+
+		if nd.val.markers[level] == nil {
+			nd.val.markers[level] = sets.New[*interval[K, V]]()
+		}
+		nd.val.markers[level].Set(iv)
+		iv.marks = append(iv.marks, mark[K, V]{node: nd, level: level})
+
+		nd = nd.tower[level].next
+	}
+}
+
+// unplace removes all the markers of the interval.
+func (il *IntervalList[K, V]) unplace(iv *interval[K, V]) {
+	for _, m := range iv.marks {
+		m.node.val.markers[m.level].Unset(iv)
+	}
+	iv.marks = iv.marks[:0]
+}
+
+func removeInterval[K, V any](intervals []*interval[K, V], iv *interval[K, V]) []*interval[K, V] {
+	for i := range intervals {
+		if intervals[i] == iv {
+			last := len(intervals) - 1
+			intervals[i] = intervals[last]
+			intervals[last] = nil
+			return intervals[:last]
+		}
+	}
+	return intervals
+}
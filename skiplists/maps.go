@@ -0,0 +1,80 @@
+package skiplists
+
+import "cmp"
+
+// Map is an ordered key-value store backed by a [SkipList].
+// Entries are ordered by their keys, and random accesses by index are supported like SkipList.
+//
+// A Map is not safe for concurrent use by multiple goroutines.
+type Map[K, V any] struct {
+	list *SkipList[entry[K, V]]
+}
+
+type entry[K, V any] struct {
+	key K
+	val V
+}
+
+// NewMap returns a [Map] of any ordered keys.
+func NewMap[K cmp.Ordered, V any](options ...Option) *Map[K, V] {
+	return NewMapFunc[K, V](cmp.Compare, options...)
+}
+
+// NewMapFunc returns a Map of any key type when a custom cmp function is provided.
+// The `cmp` function follows the same rules as the one passed to [NewFunc].
+// Keys in a Map are always unique, so option [SetDuplicates] has no effect.
+func NewMapFunc[K, V any](cmp func(a, b K) int, options ...Option) *Map[K, V] {
+	options = append(options[:len(options):len(options)], SetDuplicates(false))
+	return &Map[K, V]{
+		list: NewFunc(func(a, b entry[K, V]) int { return cmp(a.key, b.key) }, options...),
+	}
+}
+
+// Clone returns a deep copy of the Map, see [SkipList.Clone] for details.
+func (m *Map[K, V]) Clone() *Map[K, V] {
+	return &Map[K, V]{list: m.list.Clone()}
+}
+
+// Len returns number of entries in the Map.
+func (m *Map[K, V]) Len() int { return m.list.Len() }
+
+// Get returns the value associated with the key and true if the key is in the Map,
+// otherwise zero value of type V and false.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	e, ok := m.list.Get(entry[K, V]{key: key})
+	return e.val, ok
+}
+
+// Has reports whether the key is in the Map.
+func (m *Map[K, V]) Has(key K) bool {
+	_, ok := m.list.Get(entry[K, V]{key: key})
+	return ok
+}
+
+// Put associates the value with the key.
+// If the key is already in, both the key and the value will be overwritten with the input ones.
+func (m *Map[K, V]) Put(key K, val V) *Map[K, V] {
+	m.list.Set(entry[K, V]{key: key, val: val})
+	return m
+}
+
+// Delete removes the key and its associated value from the Map.
+// If the key is not found, nothing happens.
+func (m *Map[K, V]) Delete(key K) *Map[K, V] {
+	m.list.Unset(entry[K, V]{key: key})
+	return m
+}
+
+// At returns the i-th key and its associated value in the Map.
+// It panics if i is not valid, just like accessing slice element with an out-of-range index.
+func (m *Map[K, V]) At(i int) (K, V) {
+	e := m.list.At(i)
+	return e.key, e.val
+}
+
+// RemoveAt removes the i-th entry in the Map.
+// It panics if i is not valid, just like accessing slice element with an out-of-range index.
+func (m *Map[K, V]) RemoveAt(i int) *Map[K, V] {
+	m.list.RemoveAt(i)
+	return m
+}
@@ -0,0 +1,89 @@
+package skiplists
+
+// Union returns a new SkipList contains elements either in sl or in t.
+// If an element is in both, the one in sl is kept.
+//
+// Both SkipLists are expected to be ordered by the same cmp function,
+// so they are merged in linear time, and kept untouched.
+// The returned SkipList is ordered by the cmp function of sl, with same options.
+//
+// If duplicates are allowed, equal elements are paired in order,
+// and the number of each element in the result is the maximum of its numbers in sl and t.
+// Otherwise, equal elements in t are collapsed into the first one of them,
+// even if duplicates are allowed in t, so the result is always valid with the options of sl.
+func (sl *SkipList[V]) Union(t *SkipList[V]) *SkipList[V] {
+	return sl.combine(t, true, true, keepLeft[V])
+}
+
+// Intersection returns a new SkipList contains elements both in sl and in t.
+// The ones in sl are kept in the result.
+//
+// Like [Union], both SkipLists are merged in linear time.
+// If duplicates are allowed, the number of each element in the result is the minimum of its numbers in sl and t.
+func (sl *SkipList[V]) Intersection(t *SkipList[V]) *SkipList[V] {
+	return sl.combine(t, false, false, keepLeft[V])
+}
+
+// Difference returns a new SkipList contains elements in sl but not in t.
+//
+// Like [Union], both SkipLists are merged in linear time.
+// If duplicates are allowed, the number of each element in the result is its number in sl subtracted by the one in t.
+func (sl *SkipList[V]) Difference(t *SkipList[V]) *SkipList[V] {
+	return sl.combine(t, true, false, nil)
+}
+
+// Merge is like [Union], but calls resolve to decide the value kept in the result
+// if an element is in both sl and t, with a from sl and b from t.
+func (sl *SkipList[V]) Merge(t *SkipList[V], resolve func(a, b V) V) *SkipList[V] {
+	return sl.combine(t, true, true, resolve)
+}
+
+func keepLeft[V any](a, _ V) V { return a }
+
+// combine merges two sorted SkipLists into a new one.
+// Elements only in sl or t are kept if left or right is true,
+// and elements in both are resolved into one, or dropped if resolve is nil.
+func (sl *SkipList[V]) combine(t *SkipList[V], left, right bool, resolve func(a, b V) V) *SkipList[V] {
+	var values []V
+	add := func(v V) {
+		// t may have duplicates even if sl does not
+		if !sl.opt.Duplicates && len(values) > 0 && sl.cmp(values[len(values)-1], v) == 0 {
+			return
+		}
+		values = append(values, v)
+	}
+	a, b := sl.head.tower[0].next, t.head.tower[0].next
+
+	for a != nil && b != nil {
+		switch c := sl.cmp(a.val, b.val); {
+		case c < 0:
+			if left {
+				add(a.val)
+			}
+			a = a.tower[0].next
+		case c > 0:
+			if right {
+				add(b.val)
+			}
+			b = b.tower[0].next
+		default:
+			if resolve != nil {
+				add(resolve(a.val, b.val))
+			}
+			a, b = a.tower[0].next, b.tower[0].next
+		}
+	}
+
+	for ; left && a != nil; a = a.tower[0].next {
+		add(a.val)
+	}
+	for ; right && b != nil; b = b.tower[0].next {
+		add(b.val)
+	}
+
+	u := NewFunc(sl.cmp)
+	u.opt = sl.opt.clone()
+	u.fill(values)
+
+	return u
+}
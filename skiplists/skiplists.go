@@ -11,6 +11,7 @@ package skiplists
 
 import (
 	"cmp"
+	"errors"
 	"fmt"
 	"math/bits"
 	"math/rand"
@@ -20,6 +21,9 @@ import (
 // that allows $O(\log n)$ average complexity for search and insertion,
 // as well as random accesses.
 //
+// A SkipList can be encoded into JSON, binary or gob as its ordered elements,
+// see [SkipList.UnmarshalJSON] for how it is decoded.
+//
 // A SkipList is not safe for concurrent use by multiple goroutines.
 type SkipList[V any] struct {
 	head  *node[V]
@@ -31,8 +35,51 @@ type SkipList[V any] struct {
 
 type node[V any] struct {
 	val   V
-	width []int // for fast random access
-	next  []*node[V]
+	prev  *node[V] // on the lowest level only, for backward traversal
+	tower []link[V]
+}
+
+// link is a forward link of a node on some level.
+type link[V any] struct {
+	next  *node[V]
+	width int // number of elements skipped over, for fast random access
+}
+
+// newNode allocates a node along with its tower of links.
+// Towers of the lowest levels, which most of the nodes have, are allocated inline with the node,
+// so inserting an element costs only one allocation.
+func newNode[V any](val V, level int) *node[V] {
+	switch level {
+	case 1:
+		nd := &struct {
+			node[V]
+			links [1]link[V]
+		}{node: node[V]{val: val}}
+		nd.tower = nd.links[:]
+		return &nd.node
+	case 2:
+		nd := &struct {
+			node[V]
+			links [2]link[V]
+		}{node: node[V]{val: val}}
+		nd.tower = nd.links[:]
+		return &nd.node
+	case 3:
+		nd := &struct {
+			node[V]
+			links [3]link[V]
+		}{node: node[V]{val: val}}
+		nd.tower = nd.links[:]
+		return &nd.node
+	case 4:
+		nd := &struct {
+			node[V]
+			links [4]link[V]
+		}{node: node[V]{val: val}}
+		nd.tower = nd.links[:]
+		return &nd.node
+	}
+	return &node[V]{val: val, tower: make([]link[V], level)}
 }
 
 // New returns a [SkipList] of any ordered elements
@@ -50,10 +97,7 @@ func New[V cmp.Ordered](options ...Option) *SkipList[V] {
 // or for ordered types with a custom comparison operation (e.g., comparing floats within an approximate epsilon).
 func NewFunc[V any](cmp func(a, b V) int, options ...Option) *SkipList[V] {
 	sl := &SkipList[V]{
-		head: &node[V]{
-			width: []int{0},
-			next:  []*node[V]{nil},
-		},
+		head:  &node[V]{tower: make([]link[V], 1)},
 		level: 1,
 		cmp:   cmp,
 		opt:   defaultOptions,
@@ -66,16 +110,176 @@ func NewFunc[V any](cmp func(a, b V) int, options ...Option) *SkipList[V] {
 	return sl
 }
 
-// Reverse returns a new SkipList which sort the elements in reversed order.
+// FromSorted returns a [SkipList] built from the sorted values in O(n) time,
+// which is faster than calling [Set] for each of them.
+// An error is returned if values are not in ascending order,
+// or contain duplicates while [SetDuplicates] is not enabled.
+// The values slice is not retained by the SkipList.
+func FromSorted[V cmp.Ordered](values []V, options ...Option) (*SkipList[V], error) {
+	return FromSortedFunc(values, cmp.Compare[V], options...)
+}
+
+// FromSortedFunc is like [FromSorted] but orders the values with a custom cmp function,
+// which follows the same rules as the one passed to [NewFunc].
+func FromSortedFunc[V any](values []V, cmp func(a, b V) int, options ...Option) (*SkipList[V], error) {
+	sl := NewFunc(cmp, options...)
+	if err := sl.build(values); err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
+// ErrUnsorted is returned when building a SkipList from unsorted input.
+var ErrUnsorted = errors.New("skiplists: values are not sorted")
+
+// build checks the values are sorted and fills them into an empty SkipList.
+func (sl *SkipList[V]) build(values []V) error {
+	for i := 1; i < len(values); i++ {
+		switch c := sl.cmp(values[i-1], values[i]); {
+		case c > 0:
+			return fmt.Errorf("%w: values[%d] is less than its predecessor", ErrUnsorted, i)
+		case c == 0 && !sl.opt.Duplicates:
+			return fmt.Errorf("%w: values[%d] equals to its predecessor", ErrUnsorted, i)
+		}
+	}
+
+	sl.fill(values)
+	return nil
+}
+
+// fill links the sorted values into an empty SkipList level by level.
+func (sl *SkipList[V]) fill(values []V) {
+	sl.fillFunc(values, func(int) int { return sl.randomLevel() })
+}
+
+// fillFunc is like fill, but the level of the i-th value is decided by levelOf.
+func (sl *SkipList[V]) fillFunc(values []V, levelOf func(i int) int) {
+	// levels are decided as if all the values are already in
+	sl.size = len(values)
+
+	// the last node and its index on each level
+	lasts := []*node[V]{sl.head}
+	jumps := []int{-1}
+
+	for i, val := range values {
+		newLevel := levelOf(i)
+		nd := newNode(val, newLevel)
+		nd.prev = lasts[0]
+
+		if newLevel > sl.level {
+			sl.head.tower = append(sl.head.tower[:sl.level], make([]link[V], newLevel-sl.level)...)
+			for level := sl.level; level < newLevel; level++ {
+				lasts = append(lasts, sl.head)
+				jumps = append(jumps, -1)
+			}
+			sl.level = newLevel
+		}
+
+		for level := 0; level < newLevel; level++ {
+			lasts[level].tower[level].next = nd
+			lasts[level].tower[level].width = i - jumps[level]
+			lasts[level] = nd
+			jumps[level] = i
+		}
+	}
+}
+
+// Reverse returns a new SkipList which sorts the elements in reversed order.
+// The returned SkipList is independent of the original one, with the same options.
+// The complexity is O(n).
 func (sl *SkipList[V]) Reverse() *SkipList[V] {
-	return &SkipList[V]{
-		head:  sl.head,
-		level: sl.level,
-		size:  sl.size,
-		cmp:   func(a, b V) int { return sl.cmp(b, a) },
+	cmp := sl.cmp
+	r := NewFunc(func(a, b V) int { return cmp(b, a) })
+	r.opt = sl.opt.clone()
+
+	values := make([]V, sl.size)
+	i := sl.size
+	for nd := sl.head.tower[0].next; nd != nil; nd = nd.tower[0].next {
+		i--
+		values[i] = nd.val
+	}
+	r.fill(values)
+
+	return r
+}
+
+// Clone returns a deep copy of the SkipList, with the same options.
+// The inner structure is copied as is instead of being rebuilt, so the complexity is O(n).
+func (sl *SkipList[V]) Clone() *SkipList[V] {
+	c := NewFunc(sl.cmp)
+	c.opt = sl.opt.clone()
+	c.level = sl.level
+	c.size = sl.size
+
+	// copy the nodes with the widths of their links
+	c.head.tower = make([]link[V], sl.level)
+	copyWidths(c.head, sl.head)
+	last := c.head
+	for nd := sl.head.tower[0].next; nd != nil; nd = nd.tower[0].next {
+		cp := newNode(nd.val, len(nd.tower))
+		copyWidths(cp, nd)
+		cp.prev = last
+		last.tower[0].next = cp
+		last = cp
 	}
+
+	// link the copies on each level, by walking through the level below in both SkipLists side by side
+	for level := 1; level < sl.level; level++ {
+		last, target := c.head, sl.head.tower[level].next
+		src, dst := sl.head.tower[level-1].next, c.head.tower[level-1].next
+		for ; target != nil; src, dst = src.tower[level-1].next, dst.tower[level-1].next {
+			if src == target {
+				last.tower[level].next = dst
+				last, target = dst, target.tower[level].next
+			}
+		}
+	}
+
+	return c
 }
 
+// stackLevels is the number of levels that the buffers used by updates are allocated on the stack for,
+// which is enough for billions of elements.
+const stackLevels = 32
+
+// buffer returns buf resliced to n elements, or a new slice if buf is not large enough.
+func buffer[T any](buf []T, n int) []T {
+	if n > len(buf) {
+		return make([]T, n)
+	}
+	return buf[:n]
+}
+
+// copyWidths copies widths of the links in the tower of src to dst, which must not be taller than src.
+func copyWidths[V any](dst, src *node[V]) {
+	for level := range dst.tower {
+		dst.tower[level].width = src.tower[level].width
+	}
+}
+
+// Rebalance rebuilds the SkipList into a perfectly balanced one for its current size,
+// in which every 2^LogP-th node on each level is promoted to the next level,
+// so an element is found by following at most 2^LogP links on each level.
+//
+// Levels of the elements are random, so a SkipList is balanced in the long run,
+// but it could be tall and sparse after removing most of its elements.
+// Rebalance could be called after such churns, the complexity is O(n).
+// Elements inserted afterwards still get random levels as usual.
+func (sl *SkipList[V]) Rebalance() *SkipList[V] {
+	values := sl.Slice(0, sl.size)
+
+	sl.head = &node[V]{tower: make([]link[V], 1)}
+	sl.level = 1
+	sl.fillFunc(values, func(i int) int {
+		return bits.TrailingZeros(uint(i+1))/sl.opt.LogP + 1
+	})
+
+	return sl
+}
+
+// Options returns a copy of the options of the SkipList.
+func (sl *SkipList[V]) Options() Options { return sl.opt }
+
 // Len returns number of elements in the SkipList
 func (sl *SkipList[V]) Len() int { return sl.size }
 
@@ -83,86 +287,84 @@ func (sl *SkipList[V]) Len() int { return sl.size }
 // otherwise zero value of type V and false.
 // The returned value is useful when V is a custom type and the provided `cmp` method may
 // return 0 (means equal) for different values, e.g., `cmp` only compares one field of a struct.
+// If duplicates are allowed, the first one of the equal elements is returned.
 func (sl *SkipList[V]) Get(val V) (V, bool) {
-	node := sl.head
-
-	// level := min(sl.level, maxLevel(sl.opt.LogP, sl.size)) - 1
-	for level := sl.level - 1; level >= 0; level-- {
-		for node.next[level] != nil && sl.cmp(node.next[level].val, val) <= 0 {
-			node = node.next[level]
-		}
-		if sl.cmp(node.val, val) == 0 {
-			goto CHECK
-		}
-	}
-
-CHECK:
-	if node == nil || sl.cmp(node.val, val) != 0 {
+	nd, _ := sl.seek(val, false)
+	if nd.tower[0].next == nil || sl.cmp(nd.tower[0].next.val, val) != 0 {
 		var v V
 		return v, false
 	}
-	return node.val, true
+	return nd.tower[0].next.val, true
 }
 
 // Set inserts an element into the SkipList.
-// If the element is already in, the element will be overwritten with the input value.
+// If the element is already in, the element will be overwritten with the input value,
+// unless duplicates are allowed, in which case the element is inserted after all the equal ones.
 func (sl *SkipList[V]) Set(val V) *SkipList[V] {
 	// nodes in each level just before the target
-	updates := make([]*node[V], sl.level)
+	var updatesBuf [stackLevels]*node[V]
+	updates := buffer(updatesBuf[:], sl.level)
 
 	// indexes of each node when jumps to lower level
-	jumps := make([]int, sl.level)
+	var jumpsBuf [stackLevels]int
+	jumps := buffer(jumpsBuf[:], sl.level)
+	ranked := !sl.opt.NoRanks
 	pos := -1
 
 	nd := sl.head
 	for level := sl.level - 1; level >= 0; level-- {
-		for nd.next[level] != nil && sl.cmp(nd.next[level].val, val) <= 0 {
-			pos += nd.width[level]
-			nd = nd.next[level]
+		for nd.tower[level].next != nil && sl.cmp(nd.tower[level].next.val, val) <= 0 {
+			pos += nd.tower[level].width
+			nd = nd.tower[level].next
 		}
 		updates[level] = nd
 		jumps[level] = pos
 	}
 
-	if nd.next[0] != nil && sl.cmp(nd.next[0].val, val) == 0 {
+	// the search stops at the last node not greater than val
+	if !sl.opt.Duplicates && nd != sl.head && sl.cmp(nd.val, val) == 0 {
 		nd.val = val
 		return sl
 	}
 
 	newLevel := sl.randomLevel()
 
-	newNode := &node[V]{
-		val:   val,
-		width: make([]int, newLevel),
-		next:  make([]*node[V], newLevel),
-	}
+	newNode := newNode(val, newLevel)
 	pos++ // index of new node
 
 	// add new levels if needed
 	if newLevel > sl.level {
-		sl.head.next = append(sl.head.next[:sl.level], make([]*node[V], newLevel-sl.level)...)
-		sl.head.width = append(sl.head.width[:sl.level], make([]int, newLevel-sl.level)...)
+		sl.head.tower = append(sl.head.tower[:sl.level], make([]link[V], newLevel-sl.level)...)
 
 		for level := sl.level; level < newLevel; level++ {
-			sl.head.next[level] = newNode
-			sl.head.width[level] = pos + 1
-			newNode.width[level] = sl.size - pos
+			sl.head.tower[level].next = newNode
+			sl.head.tower[level].width = pos + 1
+			newNode.tower[level].width = sl.size - pos
 		}
 
 		sl.level = newLevel
 	}
 
 	for level := 0; level < min(newLevel, len(updates)); level++ {
-		newNode.next[level] = updates[level].next[level]
-		updates[level].next[level] = newNode
+		newNode.tower[level].next = updates[level].tower[level].next
+		updates[level].tower[level].next = newNode
+
+		if ranked {
+			left := pos - jumps[level]
+			newNode.tower[level].width = updates[level].tower[level].width - left + 1
+			updates[level].tower[level].width = left
+		}
+	}
 
-		left := pos - jumps[level]
-		newNode.width[level] = updates[level].width[level] - left + 1
-		updates[level].width[level] = left
+	if ranked {
+		for level := newLevel; level < len(updates); level++ {
+			updates[level].tower[level].width++
+		}
 	}
 
-	for level := newLevel; level < len(updates); level++ {
-		updates[level].width[level]++
+	newNode.prev = nd
+	if newNode.tower[0].next != nil {
+		newNode.tower[0].next.prev = newNode
 	}
 
 	sl.size++
@@ -172,64 +374,266 @@ func (sl *SkipList[V]) Set(val V) *SkipList[V] {
 
 // Unset removes an element from the SkipList.
 // If the element is not found, nothing happens.
+// If duplicates are allowed, only the first one of the equal elements is removed.
 func (sl *SkipList[V]) Unset(val V) *SkipList[V] {
-	updates := make([]*node[V], sl.level)
+	var updatesBuf [stackLevels]*node[V]
+	updates := buffer(updatesBuf[:], sl.level)
 
 	node := sl.head
 	for level := sl.level - 1; level >= 0; level-- {
-		for node.next[level] != nil && sl.cmp(node.next[level].val, val) < 0 {
-			node = node.next[level]
+		for node.tower[level].next != nil && sl.cmp(node.tower[level].next.val, val) < 0 {
+			node = node.tower[level].next
 		}
 		updates[level] = node
 	}
 
 	// the node we are removing
-	node = node.next[0]
+	node = node.tower[0].next
 	if node == nil || sl.cmp(node.val, val) != 0 {
 		return sl
 	}
 
+	if node.tower[0].next != nil {
+		node.tower[0].next.prev = updates[0]
+	}
+
 	// remove node from each level
 	for level := 0; level < sl.level; level++ {
-		if updates[level].next[level] == node {
-			updates[level].width[level] += node.width[level] - 1
-			updates[level].next[level] = node.next[level]
+		if updates[level].tower[level].next == node {
+			updates[level].tower[level].width += node.tower[level].width - 1
+			updates[level].tower[level].next = node.tower[level].next
 		} else {
-			updates[level].width[level]--
-		}
-
-		// remove higher levels contains nothing
-		if level > 1 && updates[level] == sl.head && sl.head.next[level] == nil {
-			sl.level = level - 1
-			break
+			updates[level].tower[level].width--
 		}
 	}
 
 	sl.size--
+	sl.shrink()
 
 	return sl
 }
 
-// At returns the i-th element in the SkipList.
-// It panics if i is not valid, just like accessing slice element with an out-of-range index.
-func (sl *SkipList[V]) At(i int) V {
-	if i < 0 || i >= sl.size {
-		panic(fmt.Errorf("runtime error: index out of range [%d] with skip list length %d", i, sl.size))
+// Floor returns the greatest element less than or equal to val and true,
+// or zero value of type V and false if there is no such element.
+func (sl *SkipList[V]) Floor(val V) (V, bool) {
+	nd, _ := sl.seek(val, true)
+	return sl.valueOf(nd)
+}
+
+// Ceiling returns the least element greater than or equal to val and true,
+// or zero value of type V and false if there is no such element.
+func (sl *SkipList[V]) Ceiling(val V) (V, bool) {
+	nd, _ := sl.seek(val, false)
+	return sl.valueOf(nd.tower[0].next)
+}
+
+// Lower returns the greatest element strictly less than val and true,
+// or zero value of type V and false if there is no such element.
+func (sl *SkipList[V]) Lower(val V) (V, bool) {
+	nd, _ := sl.seek(val, false)
+	return sl.valueOf(nd)
+}
+
+// Higher returns the least element strictly greater than val and true,
+// or zero value of type V and false if there is no such element.
+func (sl *SkipList[V]) Higher(val V) (V, bool) {
+	nd, _ := sl.seek(val, true)
+	return sl.valueOf(nd.tower[0].next)
+}
+
+// Range returns the ordered elements between lo and hi.
+// Whether lo and hi themselves are included is decided by b.
+func (sl *SkipList[V]) Range(lo, hi V, b Bounds) []V {
+	var values []V
+
+	nd, _ := sl.seek(lo, b&LeftOpen != 0)
+	for nd = nd.tower[0].next; nd != nil && sl.within(nd.val, hi, b); nd = nd.tower[0].next {
+		values = append(values, nd.val)
 	}
 
-	node := sl.head
+	return values
+}
+
+// IndexOf returns the index of val and true if it is in the SkipList,
+// otherwise -1 and false.
+func (sl *SkipList[V]) IndexOf(val V) (int, bool) {
+	nd, pos := sl.seek(val, false)
+	if nd.tower[0].next == nil || sl.cmp(nd.tower[0].next.val, val) != 0 {
+		return -1, false
+	}
+	return sl.rank(nd, pos) + 1, true
+}
+
+// CountLess returns number of elements less than val,
+// which is also the index val would be placed at if it were inserted.
+func (sl *SkipList[V]) CountLess(val V) int {
+	nd, pos := sl.seek(val, false)
+	return sl.rank(nd, pos) + 1
+}
+
+// CountBetween returns number of elements between lo and hi.
+// Whether lo and hi themselves are counted is decided by b.
+func (sl *SkipList[V]) CountBetween(lo, hi V, b Bounds) int {
+	i, j := sl.between(lo, hi, b)
+	return max(j-i, 0)
+}
+
+// Bounds tells whether the lower and upper bounds are included in a range.
+type Bounds uint8
+
+const (
+	// Closed includes both bounds: [lo, hi].
+	Closed Bounds = 0
+	// LeftOpen excludes the lower bound: (lo, hi].
+	LeftOpen Bounds = 1
+	// RightOpen excludes the upper bound: [lo, hi).
+	RightOpen Bounds = 2
+	// Open excludes both bounds: (lo, hi).
+	Open = LeftOpen | RightOpen
+)
+
+// seek returns the last node whose value is less than val (or equal to val if inclusive is true),
+// along with its index.
+// The head node and index -1 are returned if there is no such node.
+func (sl *SkipList[V]) seek(val V, inclusive bool) (*node[V], int) {
+	nd := sl.head
 	pos := -1
 	for level := sl.level - 1; level >= 0; level-- {
-		for node.next[level] != nil && pos+node.width[level] <= i {
-			pos += node.width[level]
-			node = node.next[level]
+		for nd.tower[level].next != nil {
+			c := sl.cmp(nd.tower[level].next.val, val)
+			if c > 0 || c == 0 && !inclusive {
+				break
+			}
+			pos += nd.tower[level].width
+			nd = nd.tower[level].next
 		}
-		if pos == i {
-			return node.val
+	}
+	return nd, pos
+}
+
+// between returns the index range [i, j) of the elements between lo and hi,
+// where j is not greater than i if there is no such element.
+func (sl *SkipList[V]) between(lo, hi V, b Bounds) (int, int) {
+	before, pos := sl.seek(lo, b&LeftOpen != 0)
+	i := sl.rank(before, pos) + 1
+	last, pos := sl.seek(hi, b&RightOpen == 0)
+	return i, sl.rank(last, pos) + 1
+}
+
+// before returns the node before the i-th one, along with its index.
+// If ranks are not tracked, the lowest level is walked through from the beginning.
+func (sl *SkipList[V]) before(i int) (*node[V], int) {
+	nd := sl.head
+	pos := -1
+	if sl.opt.NoRanks {
+		for ; pos+1 < i; pos++ {
+			nd = nd.tower[0].next
 		}
+		return nd, pos
 	}
 
-	return node.val
+	for level := sl.level - 1; level >= 0; level-- {
+		for nd.tower[level].next != nil && pos+nd.tower[level].width < i {
+			pos += nd.tower[level].width
+			nd = nd.tower[level].next
+		}
+	}
+	return nd, pos
+}
+
+// predecessors fills updates with the last node before the i-th one on each level, and returns it.
+// updates must be as long as the current level, and ranks must be tracked.
+func (sl *SkipList[V]) predecessors(i int, updates []*node[V]) []*node[V] {
+	nd := sl.head
+	pos := -1
+	for level := sl.level - 1; level >= 0; level-- {
+		for nd.tower[level].next != nil && pos+nd.tower[level].width < i {
+			pos += nd.tower[level].width
+			nd = nd.tower[level].next
+		}
+		updates[level] = nd
+	}
+	return updates
+}
+
+// rank returns the index of a node found at pos by seek or seekFunc.
+// If ranks are not tracked, pos is meaningless, and the index is counted by walking backward.
+func (sl *SkipList[V]) rank(nd *node[V], pos int) int {
+	if !sl.opt.NoRanks {
+		return pos
+	}
+	for pos = -1; nd != sl.head; nd = nd.prev {
+		pos++
+	}
+	return pos
+}
+
+// seekFunc is like seek, but the target is decided by before,
+// which must report true for a prefix of the elements and false for the rest.
+func (sl *SkipList[V]) seekFunc(before func(V) bool) (*node[V], int) {
+	nd := sl.head
+	pos := -1
+	for level := sl.level - 1; level >= 0; level-- {
+		for nd.tower[level].next != nil && before(nd.tower[level].next.val) {
+			pos += nd.tower[level].width
+			nd = nd.tower[level].next
+		}
+	}
+	return nd, pos
+}
+
+// last returns the last node, or the head if the SkipList is empty.
+func (sl *SkipList[V]) last() *node[V] {
+	nd := sl.head
+	for level := sl.level - 1; level >= 0; level-- {
+		for nd.tower[level].next != nil {
+			nd = nd.tower[level].next
+		}
+	}
+	return nd
+}
+
+// within reports whether val is not beyond the upper bound hi.
+func (sl *SkipList[V]) within(val, hi V, b Bounds) bool {
+	c := sl.cmp(val, hi)
+	return c < 0 || c == 0 && b&RightOpen == 0
+}
+
+// valueOf returns the value of an element node, the head and nil are treated as not found.
+func (sl *SkipList[V]) valueOf(nd *node[V]) (V, bool) {
+	if nd == nil || nd == sl.head {
+		var v V
+		return v, false
+	}
+	return nd.val, true
+}
+
+// UnsetOne removes the first element equal to val.
+// It is the same as [Unset], but reads better for SkipLists allowing duplicates.
+func (sl *SkipList[V]) UnsetOne(val V) *SkipList[V] {
+	return sl.Unset(val)
+}
+
+// UnsetAll removes all the elements equal to val.
+func (sl *SkipList[V]) UnsetAll(val V) *SkipList[V] {
+	return sl.RemoveBetween(val, val, Closed)
+}
+
+// Count returns number of elements equal to val.
+// It is always 0 or 1 unless duplicates are allowed.
+func (sl *SkipList[V]) Count(val V) int {
+	return sl.CountBetween(val, val, Closed)
+}
+
+// At returns the i-th element in the SkipList.
+// It panics if i is not valid, just like accessing slice element with an out-of-range index.
+func (sl *SkipList[V]) At(i int) V {
+	if i < 0 || i >= sl.size {
+		panic(fmt.Errorf("runtime error: index out of range [%d] with skip list length %d", i, sl.size))
+	}
+
+	nd, _ := sl.before(i)
+	return nd.tower[0].next.val
 }
 
 // RemoveAt removes the i-th element in the SkipList.
@@ -239,49 +643,167 @@ func (sl *SkipList[V]) RemoveAt(i int) *SkipList[V] {
 		panic(fmt.Errorf("runtime error: index out of range [%d] with skip list length %d", i, sl.size))
 	}
 
+	if sl.opt.NoRanks {
+		nd, _ := sl.before(i)
+		sl.unlink(nd.tower[0].next)
+		return sl
+	}
+
 	// unlike in [Unset], we are sure a node will be removed,
 	// so we can do the removing at the same time as we search for it.
 
 	node := sl.head
 	pos := -1
 	for level := sl.level - 1; level >= 0; level-- {
-		for node.next[level] != nil && pos+node.width[level] < i {
-			pos += node.width[level]
-			node = node.next[level]
+		for node.tower[level].next != nil && pos+node.tower[level].width < i {
+			pos += node.tower[level].width
+			node = node.tower[level].next
 		}
 
 		// the node we are removing must be in the range
-		node.width[level]--
+		node.tower[level].width--
 
 		// found the node we are removing, merge its width with the following part
-		if node.next[level] != nil && pos+node.width[level]+1 == i {
-			node.width[level] += node.next[level].width[level]
-			node.next[level] = node.next[level].next[level]
-
-			if node == sl.head && node.next[level] == nil {
-				sl.level--
+		if node.tower[level].next != nil && pos+node.tower[level].width+1 == i {
+			removed := node.tower[level].next
+			node.tower[level].width += removed.tower[level].width
+			node.tower[level].next = removed.tower[level].next
+			if level == 0 && node.tower[0].next != nil {
+				node.tower[0].next.prev = node
 			}
 		}
 	}
 
 	sl.size--
+	sl.shrink()
 
 	return sl
 }
 
-func maxLevel(logP, size int) int {
-	return bits.Len64(uint64(size)+1) / logP
+// Slice returns the elements with indexes in [i, j), like slicing a slice with s[i:j].
+// It panics if the range is not valid, just like slicing a slice with out-of-range indexes.
+func (sl *SkipList[V]) Slice(i, j int) []V {
+	sl.checkRange(i, j)
+
+	nd, _ := sl.before(i)
+	values := make([]V, 0, j-i)
+	for nd = nd.tower[0].next; len(values) < j-i; nd = nd.tower[0].next {
+		values = append(values, nd.val)
+	}
+
+	return values
 }
 
-func (sl *SkipList[V]) randomLevel() int {
-	level := sl.opt.maxLevel
-	if sl.opt.SizeHint < sl.size {
-		level = maxLevel(sl.opt.LogP, sl.size)
+// RemoveRange removes the elements with indexes in [i, j).
+// The nodes are unlinked in a single pass, which is much faster than calling [RemoveAt] repeatedly.
+// It panics if the range is not valid, just like slicing a slice with out-of-range indexes.
+func (sl *SkipList[V]) RemoveRange(i, j int) *SkipList[V] {
+	sl.checkRange(i, j)
+	sl.removeRange(i, j)
+	return sl
+}
+
+// RemoveBetween removes the elements between lo and hi.
+// Whether lo and hi themselves are removed is decided by b.
+func (sl *SkipList[V]) RemoveBetween(lo, hi V, b Bounds) *SkipList[V] {
+	i, j := sl.between(lo, hi, b)
+	if j > i {
+		sl.removeRange(i, j)
 	}
-	level = bits.TrailingZeros64(rand.Uint64()|(1<<(level*sl.opt.LogP))) / sl.opt.LogP
-	return level + 1
+	return sl
 }
 
+func (sl *SkipList[V]) checkRange(i, j int) {
+	if i < 0 || j < i || j > sl.size {
+		panic(fmt.Errorf("runtime error: slice bounds out of range [%d:%d] with skip list length %d", i, j, sl.size))
+	}
+}
+
+// unlink removes the node from all the levels, without maintaining the widths,
+// so it is only used if ranks are not tracked.
+func (sl *SkipList[V]) unlink(target *node[V]) {
+	nd := sl.head
+	for level := sl.level - 1; level >= 0; level-- {
+		for nd.tower[level].next != nil && sl.cmp(nd.tower[level].next.val, target.val) < 0 {
+			nd = nd.tower[level].next
+		}
+
+		if level >= len(target.tower) {
+			continue
+		}
+
+		// the target may be after some elements equal to it
+		pred := nd
+		for next := pred.tower[level].next; next != nil && next != target && sl.cmp(next.val, target.val) == 0; next = pred.tower[level].next {
+			pred = next
+		}
+		if pred.tower[level].next == target {
+			pred.tower[level].next = target.tower[level].next
+		}
+	}
+
+	if next := target.tower[0].next; next != nil {
+		next.prev = target.prev
+	}
+	sl.size--
+	sl.shrink()
+}
+
+// removeRange unlinks the nodes with indexes in [i, j).
+func (sl *SkipList[V]) removeRange(i, j int) {
+	n := j - i
+	if n <= 0 {
+		return
+	}
+
+	if sl.opt.NoRanks {
+		nd, _ := sl.before(i)
+		for ; n > 0; n-- {
+			sl.unlink(nd.tower[0].next)
+		}
+		return
+	}
+
+	nd := sl.head
+	pos := -1
+	for level := sl.level - 1; level >= 0; level-- {
+		// the last node before the range
+		for nd.tower[level].next != nil && pos+nd.tower[level].width < i {
+			pos += nd.tower[level].width
+			nd = nd.tower[level].next
+		}
+
+		// the first node after the range
+		next, nextPos := nd.tower[level].next, pos+nd.tower[level].width
+		for next != nil && nextPos < j {
+			nextPos += next.tower[level].width
+			next = next.tower[level].next
+		}
+
+		nd.tower[level].next = next
+		nd.tower[level].width = nextPos - pos - n
+		if level == 0 && next != nil {
+			next.prev = nd
+		}
+	}
+
+	sl.size -= n
+	sl.shrink()
+}
+
+// shrink removes the higher levels containing nothing, while the lowest level is always kept.
+func (sl *SkipList[V]) shrink() {
+	for sl.level > 1 && sl.head.tower[sl.level-1].next == nil {
+		sl.level--
+	}
+}
+
+func maxLevel(logP, size int) int {
+	return bits.Len64(uint64(size)+1) / logP
+}
+
+func (sl *SkipList[V]) randomLevel() int { return sl.opt.randomLevel(sl.size) }
+
 // Options represents the internal configurations of a SkipList.
 type Options struct {
 
@@ -303,6 +825,44 @@ type Options struct {
 	// If it is not set, max level will be calculated based on current size dynamically.
 	SizeHint int
 	maxLevel int
+
+	// Duplicates allows the SkipList to hold multiple elements which are equal to each other,
+	// i.e., to be a multiset. Equal elements are kept in their insertion order.
+	Duplicates bool
+
+	// NoRanks disables tracking ranks of the elements, i.e., widths of the links,
+	// which saves some work on each Set, at the cost of making the positional methods,
+	// like [SkipList.At] and [SkipList.IndexOf], walk through the elements in O(n) time.
+	NoRanks bool
+
+	rand *rand.Rand
+}
+
+// clone returns a copy of o for another SkipList.
+// The rand source is not shared, but forked from the original one to keep reproducible.
+func (o Options) clone() Options {
+	if o.rand != nil {
+		o.rand = rand.New(rand.NewSource(o.rand.Int63()))
+	}
+	return o
+}
+
+// randomLevel returns the level of a new element for a skip list holding size elements.
+func (o *Options) randomLevel(size int) int {
+	level := o.maxLevel
+	if o.SizeHint < size {
+		level = maxLevel(o.LogP, size)
+	}
+	level = bits.TrailingZeros64(o.uint64()|(1<<(level*o.LogP))) / o.LogP
+	return level + 1
+}
+
+// uint64 returns a random number from the provided source, or the global one if not provided.
+func (o *Options) uint64() uint64 {
+	if o.rand == nil {
+		return rand.Uint64()
+	}
+	return o.rand.Uint64()
 }
 
 // Option changes a SkipList's Options
@@ -324,6 +884,34 @@ func SetSizeHint(hint int) Option {
 	}
 }
 
+// SetDuplicates sets whether equal elements are allowed to coexist in the SkipList.
+func SetDuplicates(allow bool) Option {
+	return func(o *Options) {
+		o.Duplicates = allow
+	}
+}
+
+// SetRanks sets whether ranks of the elements are tracked, which is enabled by default.
+// It could be disabled if the elements are rarely accessed by their indexes, see [Options.NoRanks].
+// A SortedSet, an AggregateList and an IntervalList always track ranks.
+func SetRanks(track bool) Option {
+	return func(o *Options) {
+		o.NoRanks = !track
+	}
+}
+
+// SetRandSource sets the source of randomness used to decide the levels of new elements.
+// By default the global source of math/rand is used.
+//
+// With a deterministic source, the inner structure of a SkipList is reproducible,
+// and the SkipList avoids contention on the global source.
+// The source is not required to be safe for concurrent use, but it should not be shared with other SkipLists.
+func SetRandSource(src rand.Source) Option {
+	return func(o *Options) {
+		o.rand = rand.New(src)
+	}
+}
+
 var defaultOptions = Options{
 	LogP: 1, // P = 0.5
 }
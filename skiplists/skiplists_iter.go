@@ -7,14 +7,102 @@ import "iter"
 // All returns an iterator that yields all the ordered elements in the SkipList.
 func (sl *SkipList[V]) All() iter.Seq2[int, V] {
 	return func(yield func(int, V) bool) {
-		node := sl.head.next[0]
+		node := sl.head.tower[0].next
 		i := 0
 		for node != nil {
 			if !yield(i, node.val) {
 				return
 			}
 			i++
-			node = node.next[0]
+			node = node.tower[0].next
+		}
+	}
+}
+
+// All returns an iterator that yields all the entries in the Map ordered by keys.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, e := range m.list.All() {
+			if !yield(e.key, e.val) {
+				return
+			}
+		}
+	}
+}
+
+// Between returns an iterator that yields the ordered elements between lo and hi, along with their indexes.
+// Whether lo and hi themselves are included is decided by b.
+func (sl *SkipList[V]) Between(lo, hi V, b Bounds) iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		node, pos := sl.seek(lo, b&LeftOpen != 0)
+		i := sl.rank(node, pos)
+		for node = node.tower[0].next; node != nil && sl.within(node.val, hi, b); node = node.tower[0].next {
+			i++
+			if !yield(i, node.val) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator that yields all the ordered elements in the skip list, along with their indexes.
+// The elements are taken from a [Concurrent.Snapshot] when the iteration starts,
+// so concurrent writes during the iteration are not visible.
+func (c *Concurrent[V]) All() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		c.Snapshot().All()(yield)
+	}
+}
+
+// Backward returns an iterator that yields all the elements in the SkipList in backward order,
+// along with their indexes.
+func (sl *SkipList[V]) Backward() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		node := sl.last()
+		i := sl.size - 1
+		for node != sl.head {
+			if !yield(i, node.val) {
+				return
+			}
+			i--
+			node = node.prev
+		}
+	}
+}
+
+// All returns an iterator that yields all the intervals ordered by their lower endpoints.
+// The order of intervals with the same lower endpoint is not specified.
+func (il *IntervalList[K, V]) All() iter.Seq[Interval[K, V]] {
+	return func(yield func(Interval[K, V]) bool) {
+		for node := il.list.head.tower[0].next; node != nil; node = node.tower[0].next {
+			for _, iv := range node.val.starts {
+				if !yield(iv.Interval) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All returns an iterator that yields all the ordered elements in the AggregateList.
+func (al *AggregateList[V, A]) All() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		for i, v := range al.list.All() {
+			if !yield(i, v.val) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator that yields all the members with their scores,
+// ordered from the lowest score to the highest.
+func (ss *SortedSet[M, S]) All() iter.Seq2[M, S] {
+	return func(yield func(M, S) bool) {
+		for _, s := range ss.list.All() {
+			if !yield(s.Member, s.Score) {
+				return
+			}
 		}
 	}
 }
@@ -0,0 +1,145 @@
+package skiplists
+
+import "cmp"
+
+// SortedSet is a set of unique members ordered by their scores, like the [sorted sets] in Redis.
+// Members with the same score are ordered by themselves, just like what Redis does lexicographically.
+//
+// A SortedSet combines a hash map from members to their scores,
+// and a [SkipList] ordered by (score, member) pairs.
+// Looking up the score of a member costs O(1), while others cost O(log n).
+//
+// A SortedSet is not safe for concurrent use by multiple goroutines.
+//
+// [sorted sets]: https://redis.io/docs/data-types/sorted-sets/
+type SortedSet[M comparable, S cmp.Ordered] struct {
+	scores map[M]S
+	list   *SkipList[Scored[M, S]]
+}
+
+// Scored is a member of a SortedSet with its score.
+type Scored[M comparable, S cmp.Ordered] struct {
+	Member M
+	Score  S
+}
+
+// NewSortedSet returns a [SortedSet] of any ordered members.
+func NewSortedSet[M, S cmp.Ordered](options ...Option) *SortedSet[M, S] {
+	return NewSortedSetFunc[M, S](cmp.Compare[M], options...)
+}
+
+// NewSortedSetFunc returns a SortedSet of any comparable members,
+// the `cmpMember` function orders members with the same score, and follows the same rules as the one passed to [NewFunc].
+// Members in a SortedSet are always unique and ranked, so options [SetDuplicates] and [SetRanks] have no effect.
+func NewSortedSetFunc[M comparable, S cmp.Ordered](cmpMember func(a, b M) int, options ...Option) *SortedSet[M, S] {
+	options = append(options[:len(options):len(options)], SetDuplicates(false), SetRanks(true))
+	return &SortedSet[M, S]{
+		scores: make(map[M]S),
+		list: NewFunc(func(a, b Scored[M, S]) int {
+			if c := cmp.Compare(a.Score, b.Score); c != 0 {
+				return c
+			}
+			return cmpMember(a.Member, b.Member)
+		}, options...),
+	}
+}
+
+// Len returns number of members in the SortedSet.
+func (ss *SortedSet[M, S]) Len() int { return len(ss.scores) }
+
+// Add inserts the member with its score into the SortedSet.
+// If the member is already in, its score is updated.
+func (ss *SortedSet[M, S]) Add(member M, score S) *SortedSet[M, S] {
+	if old, ok := ss.scores[member]; ok {
+		if old == score {
+			return ss
+		}
+		ss.list.Unset(Scored[M, S]{Member: member, Score: old})
+	}
+
+	ss.scores[member] = score
+	ss.list.Set(Scored[M, S]{Member: member, Score: score})
+	return ss
+}
+
+// IncrBy increases score of the member by delta and returns the new score.
+// If the member is not in the SortedSet, it is added with delta as its score.
+func (ss *SortedSet[M, S]) IncrBy(member M, delta S) S {
+	score := ss.scores[member] + delta
+	ss.Add(member, score)
+	return score
+}
+
+// Remove removes the member from the SortedSet.
+// If the member is not found, nothing happens.
+func (ss *SortedSet[M, S]) Remove(member M) *SortedSet[M, S] {
+	if score, ok := ss.scores[member]; ok {
+		delete(ss.scores, member)
+		ss.list.Unset(Scored[M, S]{Member: member, Score: score})
+	}
+	return ss
+}
+
+// Score returns score of the member and true if it is in the SortedSet,
+// otherwise zero value of type S and false.
+func (ss *SortedSet[M, S]) Score(member M) (S, bool) {
+	score, ok := ss.scores[member]
+	return score, ok
+}
+
+// Rank returns the 0-based rank of the member ordered from the lowest score to the highest,
+// and true if it is in the SortedSet, otherwise -1 and false.
+func (ss *SortedSet[M, S]) Rank(member M) (int, bool) {
+	score, ok := ss.scores[member]
+	if !ok {
+		return -1, false
+	}
+	return ss.list.IndexOf(Scored[M, S]{Member: member, Score: score})
+}
+
+// RangeByScore returns the members with scores between lo and hi, ordered from the lowest score to the highest.
+// Whether lo and hi themselves are included is decided by b.
+func (ss *SortedSet[M, S]) RangeByScore(lo, hi S, b Bounds) []Scored[M, S] {
+	var members []Scored[M, S]
+
+	nd, _ := ss.list.seekFunc(func(v Scored[M, S]) bool {
+		return v.Score < lo || v.Score == lo && b&LeftOpen != 0
+	})
+	for nd = nd.tower[0].next; nd != nil; nd = nd.tower[0].next {
+		if nd.val.Score > hi || nd.val.Score == hi && b&RightOpen != 0 {
+			break
+		}
+		members = append(members, nd.val)
+	}
+
+	return members
+}
+
+// RangeByRank returns the members with ranks in [i, j), ordered from the lowest score to the highest.
+// It panics if the range is not valid, just like slicing a slice with out-of-range indexes.
+func (ss *SortedSet[M, S]) RangeByRank(i, j int) []Scored[M, S] {
+	return ss.list.Slice(i, j)
+}
+
+// PopMin removes and returns the member with the lowest score and true,
+// or zero value and false if the SortedSet is empty.
+func (ss *SortedSet[M, S]) PopMin() (Scored[M, S], bool) {
+	return ss.popAt(0)
+}
+
+// PopMax removes and returns the member with the highest score and true,
+// or zero value and false if the SortedSet is empty.
+func (ss *SortedSet[M, S]) PopMax() (Scored[M, S], bool) {
+	return ss.popAt(ss.Len() - 1)
+}
+
+func (ss *SortedSet[M, S]) popAt(i int) (Scored[M, S], bool) {
+	if ss.Len() == 0 {
+		return Scored[M, S]{}, false
+	}
+
+	s := ss.list.At(i)
+	ss.list.RemoveAt(i)
+	delete(ss.scores, s.Member)
+	return s, true
+}
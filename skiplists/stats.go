@@ -0,0 +1,140 @@
+package skiplists
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Stats describes the inner structure of a SkipList,
+// which is useful to tune the options like [SetLogP] and [SetSizeHint].
+type Stats struct {
+	// Len is number of elements.
+	Len int
+
+	// Level is number of levels in use.
+	Level int
+
+	// Nodes is number of nodes on each level, Nodes[0] equals to Len.
+	Nodes []int
+
+	// AvgSearchPath is the average number of links followed to find an element from the head,
+	// it is 0 if ranks are not tracked, see [SetRanks].
+	AvgSearchPath float64
+
+	// TowerBytes is the memory allocated for the links and widths of all the nodes, including the head.
+	TowerBytes int
+}
+
+// Stats collects statistics about the inner structure of the SkipList.
+// The complexity is O(n log n), as every element is searched once for the search path length.
+func (sl *SkipList[V]) Stats() Stats {
+	st := Stats{
+		Len:   sl.size,
+		Level: sl.level,
+		Nodes: make([]int, sl.level),
+	}
+
+	const linkBytes = int(unsafe.Sizeof(link[V]{}))
+	st.TowerBytes = linkBytes * cap(sl.head.tower)
+
+	for level := 0; level < sl.level; level++ {
+		for nd := sl.head.tower[level].next; nd != nil; nd = nd.tower[level].next {
+			st.Nodes[level]++
+		}
+	}
+
+	steps := 0
+	for nd, pos := sl.head.tower[0].next, 0; nd != nil; nd, pos = nd.tower[0].next, pos+1 {
+		st.TowerBytes += linkBytes * cap(nd.tower)
+		if !sl.opt.NoRanks {
+			steps += sl.searchPath(pos)
+		}
+	}
+	if sl.size > 0 {
+		st.AvgSearchPath = float64(steps) / float64(sl.size)
+	}
+
+	return st
+}
+
+// searchPath returns number of links followed to find the i-th element.
+func (sl *SkipList[V]) searchPath(i int) int {
+	steps := 0
+	nd := sl.head
+	pos := -1
+	for level := sl.level - 1; level >= 0 && pos < i; level-- {
+		for nd.tower[level].next != nil && pos+nd.tower[level].width <= i {
+			pos += nd.tower[level].width
+			nd = nd.tower[level].next
+			steps++
+		}
+	}
+	return steps
+}
+
+// Validate checks the inner structure of the SkipList, and returns an error describing the first problem found.
+// It checks that the elements are ordered, the links on each level are consistent with the lowest one,
+// the widths of the links match the distances between the nodes if ranks are tracked,
+// and there are no empty levels left on the top.
+//
+// A SkipList should always be valid, Validate is intended to be used in tests.
+// The complexity is O(n).
+func (sl *SkipList[V]) Validate() error {
+	if sl.level < 1 || sl.level > len(sl.head.tower) {
+		return fmt.Errorf("skiplists: level %d is out of range of the head with %d links", sl.level, len(sl.head.tower))
+	}
+	if sl.level > 1 && sl.head.tower[sl.level-1].next == nil {
+		return fmt.Errorf("skiplists: top level %d is empty", sl.level-1)
+	}
+
+	// positions of the nodes on the lowest level
+	positions := make(map[*node[V]]int, sl.size)
+	positions[sl.head] = -1
+
+	prev := sl.head
+	pos := 0
+	for nd := sl.head.tower[0].next; nd != nil; nd = nd.tower[0].next {
+		if nd.prev != prev {
+			return fmt.Errorf("skiplists: backward link of element %d is broken", pos)
+		}
+		if prev != sl.head {
+			if c := sl.cmp(prev.val, nd.val); c > 0 || c == 0 && !sl.opt.Duplicates {
+				return fmt.Errorf("skiplists: element %d is out of order", pos)
+			}
+		}
+		if _, ok := positions[nd]; ok {
+			return fmt.Errorf("skiplists: element %d links back to a previous node", pos)
+		}
+
+		positions[nd] = pos
+		prev = nd
+		pos++
+	}
+
+	if pos != sl.size {
+		return fmt.Errorf("skiplists: length is %d but %d elements are linked", sl.size, pos)
+	}
+
+	for level := 0; level < sl.level; level++ {
+		from := -1
+		for nd := sl.head; nd.tower[level].next != nil; nd = nd.tower[level].next {
+			next := nd.tower[level].next
+			to, ok := positions[next]
+			if !ok {
+				return fmt.Errorf("skiplists: element after %d on level %d is not linked on the lowest level", from, level)
+			}
+			if to <= from {
+				return fmt.Errorf("skiplists: element %d on level %d links backward to %d", from, level, to)
+			}
+			if len(next.tower) <= level {
+				return fmt.Errorf("skiplists: element %d on level %d has a tower of %d levels", to, level, len(next.tower))
+			}
+			if !sl.opt.NoRanks && nd.tower[level].width != to-from {
+				return fmt.Errorf("skiplists: link from %d to %d on level %d has width %d", from, to, level, nd.tower[level].width)
+			}
+			from = to
+		}
+	}
+
+	return nil
+}